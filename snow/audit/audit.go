@@ -0,0 +1,273 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const defaultPageSize = 1000
+
+var (
+	ErrInvalidSource = errors.New("invalid audit source")
+	ErrNotCreatedYet = errors.New("record did not exist at the requested time")
+)
+
+// Client reads the change history of records from sys_audit and sys_history_line.
+type Client struct {
+	r snow.Requester
+}
+
+func New(r snow.Requester) (*Client, error) {
+	if r == nil {
+		return nil, table.ErrNilRequester
+	}
+	return &Client{r: r}, nil
+}
+
+// History returns the field changes of the record sysID in tableName in
+// chronological order, paging through all matching audit records.
+func (c *Client) History(ctx context.Context, tableName, sysID string, opts *HistoryOptions) ([]Event, error) {
+	if c == nil || c.r == nil {
+		return nil, table.ErrNilRequester
+	}
+
+	tableName, sysID, err := validRecord(tableName, sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	o := HistoryOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PageSize < 0 {
+		return nil, table.ErrInvalidLimit
+	}
+	if o.PageSize == 0 {
+		o.PageSize = defaultPageSize
+	}
+
+	switch o.Source {
+	case "", SourceAudit:
+		return c.auditEvents(ctx, tableName, sysID, &o)
+	case SourceHistory:
+		return c.historyEvents(ctx, tableName, sysID, &o)
+	case SourceAuto:
+		events, err := c.historyEvents(ctx, tableName, sysID, &o)
+		var apiErr *snow.APIError
+		if errors.As(err, &apiErr) && (apiErr.Status == http.StatusForbidden || apiErr.Status == http.StatusNotFound) {
+			err = nil
+		}
+		if err != nil || len(events) > 0 {
+			return events, err
+		}
+		return c.auditEvents(ctx, tableName, sysID, &o)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidSource, o.Source)
+	}
+}
+
+// StateAt reconstructs the record sysID as it was at the time at: the current
+// record with the sys_audit changes made after at reverse-applied. Values are
+// raw (not display) values. System fields that are not audited, such as
+// sys_updated_on and sys_mod_count, keep their current values.
+func (c *Client) StateAt(ctx context.Context, tableName, sysID string, at time.Time) (map[string]any, error) {
+	if c == nil || c.r == nil {
+		return nil, table.ErrNilRequester
+	}
+
+	tableName, sysID, err := validRecord(tableName, sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := table.NewMap(c.r, tableName)
+	if err != nil {
+		return nil, err
+	}
+	// Audit values are raw strings, so read reference fields the same way.
+	current, err := records.Get(ctx, sysID, &table.GetOptions{
+		DisplayValue:         table.DisplayValue(table.DisplayValueFalse),
+		ExcludeReferenceLink: table.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if created, ok := current.Result["sys_created_on"].(string); ok {
+		if t, err := time.Parse(table.DateTimeLayout, created); err == nil && t.After(at) {
+			return nil, ErrNotCreatedYet
+		}
+	}
+
+	events, err := c.History(ctx, tableName, sysID, &HistoryOptions{Since: at})
+	if err != nil {
+		return nil, err
+	}
+
+	return Reconstruct(current.Result, events, at), nil
+}
+
+// Reconstruct returns a copy of current with every event after at undone,
+// newest first, by restoring its OldValue. current is not modified.
+func Reconstruct(current map[string]any, events []Event, at time.Time) map[string]any {
+	state := maps.Clone(current)
+	if state == nil {
+		state = map[string]any{}
+	}
+
+	sorted := make([]Event, 0, len(events))
+	for _, e := range events {
+		if e.When.After(at) {
+			sorted = append(sorted, e)
+		}
+	}
+	sort.SliceStable(sorted, func(i, k int) bool {
+		if !sorted[i].When.Equal(sorted[k].When) {
+			return sorted[i].When.After(sorted[k].When)
+		}
+		return sorted[i].RecordCheckpoint > sorted[k].RecordCheckpoint
+	})
+
+	for _, e := range sorted {
+		state[e.Field] = e.OldValue
+	}
+	return state
+}
+
+func (c *Client) auditEvents(ctx context.Context, tableName, sysID string, o *HistoryOptions) ([]Event, error) {
+	qb := table.NewQueryBuilder().
+		Eq("tablename", tableName).
+		Eq("documentkey", sysID)
+	query, err := filterQuery(qb, "fieldname", "sys_created_on", o)
+	if err != nil {
+		return nil, err
+	}
+	query += "^ORDERBYsys_created_on^ORDERBYrecord_checkpoint"
+
+	records, err := listAll[auditRecord](ctx, c.r, "sys_audit", query, o.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(records))
+	for _, rec := range records {
+		when, err := time.Parse(table.DateTimeLayout, rec.SysCreatedOn)
+		if err != nil {
+			return nil, fmt.Errorf("parse sys_created_on of sys_audit %s: %w", rec.SysID, err)
+		}
+		checkpoint, _ := strconv.Atoi(rec.RecordCheckpoint)
+		events = append(events, Event{
+			SysID:            rec.SysID,
+			Source:           SourceAudit,
+			Field:            rec.FieldName,
+			OldValue:         rec.OldValue,
+			NewValue:         rec.NewValue,
+			User:             rec.User,
+			When:             when,
+			RecordCheckpoint: checkpoint,
+			Reason:           rec.Reason,
+		})
+	}
+	return events, nil
+}
+
+func (c *Client) historyEvents(ctx context.Context, tableName, sysID string, o *HistoryOptions) ([]Event, error) {
+	qb := table.NewQueryBuilder().
+		Eq("set.id", sysID).
+		Eq("set.table", tableName)
+	query, err := filterQuery(qb, "field", "update_time", o)
+	if err != nil {
+		return nil, err
+	}
+	query += "^ORDERBYupdate_time^ORDERBYupdate"
+
+	records, err := listAll[historyLineRecord](ctx, c.r, "sys_history_line", query, o.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(records))
+	for _, rec := range records {
+		when, err := time.Parse(table.DateTimeLayout, rec.UpdateTime)
+		if err != nil {
+			return nil, fmt.Errorf("parse update_time of sys_history_line %s: %w", rec.SysID, err)
+		}
+		checkpoint, _ := strconv.Atoi(rec.Update)
+		events = append(events, Event{
+			SysID:            rec.SysID,
+			Source:           SourceHistory,
+			Field:            rec.Field,
+			OldValue:         rec.Old,
+			NewValue:         rec.New,
+			User:             rec.UserName,
+			When:             when,
+			RecordCheckpoint: checkpoint,
+		})
+	}
+	return events, nil
+}
+
+func filterQuery(qb *table.QueryBuilder, fieldColumn, timeColumn string, o *HistoryOptions) (string, error) {
+	if len(o.Fields) > 0 {
+		fields := make([]any, len(o.Fields))
+		for i, f := range o.Fields {
+			fields[i] = f
+		}
+		qb.In(fieldColumn, fields...)
+	}
+	if !o.Since.IsZero() {
+		qb.GT(timeColumn, o.Since)
+	}
+	if !o.Until.IsZero() {
+		qb.LTE(timeColumn, o.Until)
+	}
+	return qb.Build()
+}
+
+func listAll[T any](ctx context.Context, r snow.Requester, tableName, query string, pageSize int) ([]T, error) {
+	c, err := table.New[T](r, tableName, table.WithStructFields())
+	if err != nil {
+		return nil, err
+	}
+
+	var out []T
+	for offset := 0; ; offset += pageSize {
+		resp, err := c.List(ctx, &table.ListOptions{
+			Query:                    query,
+			Limit:                    table.Int(pageSize),
+			Offset:                   table.Int(offset),
+			ExcludeReferenceLink:     table.Bool(true),
+			SuppressPaginationHeader: table.Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Result...)
+		if len(resp.Result) < pageSize {
+			return out, nil
+		}
+	}
+}
+
+func validRecord(tableName, sysID string) (string, string, error) {
+	tableName = strings.TrimSpace(tableName)
+	if tableName == "" || strings.ContainsAny(tableName, `/\\`) {
+		return "", "", table.ErrInvalidTableName
+	}
+	sysID = strings.TrimSpace(sysID)
+	if sysID == "" || strings.ContainsAny(sysID, `/\\`) {
+		return "", "", table.ErrInvalidSysID
+	}
+	return tableName, sysID, nil
+}
@@ -0,0 +1,178 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/internal/snowtest"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	c, err := New(snowtest.NewClient(t, h))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+const auditPage = `{"result":[
+	{"sys_id":"a1","fieldname":"state","oldvalue":"1","newvalue":"2","user":"beth.anglin","sys_created_on":"2024-01-15 10:00:00","record_checkpoint":"1"},
+	{"sys_id":"a2","fieldname":"priority","oldvalue":"3","newvalue":"1","user":"beth.anglin","sys_created_on":"2024-01-15 11:00:00","record_checkpoint":"2"},
+	{"sys_id":"a3","fieldname":"state","oldvalue":"2","newvalue":"6","user":"abel.tuter","sys_created_on":"2024-01-15 12:00:00","record_checkpoint":"3"}
+]}`
+
+func TestHistory(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		want := "tablename=incident^documentkey=inc1^fieldnameINstate,priority^ORDERBYsys_created_on^ORDERBYrecord_checkpoint"
+		if r.URL.Path != "/api/now/table/sys_audit" || r.URL.Query().Get("sysparm_query") != want {
+			t.Errorf("request = %s?%s", r.URL.Path, r.URL.Query().Get("sysparm_query"))
+		}
+		_, _ = w.Write([]byte(auditPage))
+	})
+
+	events, err := c.History(context.Background(), "incident", "inc1", &HistoryOptions{Fields: []string{"state", "priority"}})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(events) != 3 {
+		t.Fatalf("len(events) = %d, want 3", len(events))
+	}
+	if e := events[2]; e.Field != "state" || e.OldValue != "2" || e.NewValue != "6" || e.User != "abel.tuter" ||
+		e.RecordCheckpoint != 3 || e.When.Hour() != 12 || e.Source != SourceAudit {
+		t.Errorf("events[2] = %+v", e)
+	}
+}
+
+func TestHistoryAutoFallsBackToAudit(t *testing.T) {
+	var paths []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/api/now/table/sys_history_line" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"error":{"message":"User Not Authorized"},"status":"failure"}`))
+			return
+		}
+		_, _ = w.Write([]byte(auditPage))
+	})
+
+	events, err := c.History(context.Background(), "incident", "inc1", &HistoryOptions{Source: SourceAuto})
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(events) != 3 || len(paths) != 2 {
+		t.Fatalf("History() = %d events after %v", len(events), paths)
+	}
+}
+
+func TestStateAt(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/now/table/incident/inc1":
+			q := r.URL.Query()
+			if q.Get("sysparm_display_value") != "false" || q.Get("sysparm_exclude_reference_link") != "true" {
+				_, _ = w.Write([]byte(`{"result":{"sys_id":"inc1","state":"6","priority":"1","sys_created_on":"2024-01-15 09:00:00",
+					"caller_id":{"link":"https://x/api/now/table/sys_user/u1","value":"u1"}}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"result":{"sys_id":"inc1","state":"6","priority":"1","sys_created_on":"2024-01-15 09:00:00","caller_id":"u1"}}`))
+		case "/api/now/table/sys_audit":
+			want := "tablename=incident^documentkey=inc1^sys_created_on>2024-01-15 10:30:00^ORDERBYsys_created_on^ORDERBYrecord_checkpoint"
+			if got := r.URL.Query().Get("sysparm_query"); got != want {
+				t.Errorf("sysparm_query = %q, want %q", got, want)
+			}
+			_, _ = w.Write([]byte(auditPage))
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	at := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	state, err := c.StateAt(context.Background(), "incident", "inc1", at)
+	if err != nil {
+		t.Fatalf("StateAt() error = %v", err)
+	}
+	if state["state"] != "2" || state["priority"] != "3" || state["caller_id"] != "u1" {
+		t.Fatalf("StateAt() = %v", state)
+	}
+
+	if _, err := c.StateAt(context.Background(), "incident", "inc1", at.Add(-2*time.Hour)); !errors.Is(err, ErrNotCreatedYet) {
+		t.Fatalf("StateAt() before creation error = %v, want ErrNotCreatedYet", err)
+	}
+}
+
+func TestDeletions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		want := "sys_updated_on>2024-01-15 10:00:00^tablename=incident^NQsys_updated_on=2024-01-15 10:00:00^sys_id>d0^tablename=incident" +
+			"^ORDERBYsys_updated_on^ORDERBYsys_id"
+		if r.URL.Path != "/api/now/table/sys_audit_delete" || q.Get("sysparm_query") != want {
+			t.Errorf("request = %s?%s", r.URL.Path, q.Get("sysparm_query"))
+		}
+		_, _ = w.Write([]byte(`{"result":[
+			{"sys_id":"d1","tablename":"incident","documentkey":"inc1","display_value":"INC0010001","sys_created_on":"2024-01-15 11:00:00","sys_updated_on":"2024-01-15 11:00:00",
+			 "payload":"<?xml version=\"1.0\" encoding=\"UTF-8\"?><record_update table=\"incident\"><incident action=\"DELETE\"><number>INC0010001</number><short_description>Printer &amp; scanner</short_description><assigned_to/></incident></record_update>"},
+			{"sys_id":"d2","tablename":"incident","documentkey":"inc2","sys_created_on":"2024-01-15 11:05:00","sys_updated_on":"2024-01-15 11:05:00","payload":""}
+		]}`))
+	})
+
+	since := table.Checkpoint{UpdatedOn: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC), SysID: "d0"}
+	page, err := c.Deletions(context.Background(), "incident", since, nil)
+	if err != nil {
+		t.Fatalf("Deletions() error = %v", err)
+	}
+	if len(page.Result) != 2 || page.Next.SysID != "d2" || page.More {
+		t.Fatalf("Deletions() = %+v", page)
+	}
+
+	d := page.Result[0]
+	if d.SysID != "inc1" || d.DisplayValue != "INC0010001" || d.DeletedOn.Hour() != 11 ||
+		d.Record["short_description"] != "Printer & scanner" || d.Record["assigned_to"] != "" || len(d.Record) != 3 {
+		t.Errorf("Result[0] = %+v", d)
+	}
+	if page.Result[1].Record != nil {
+		t.Errorf("Result[1].Record = %v, want nil", page.Result[1].Record)
+	}
+}
+
+func TestSyncDeletions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sysparm_limit") != "5" || q.Get("sysparm_query") != "tablename=incident^ORDERBYsys_updated_on^ORDERBYsys_id" {
+			t.Errorf("request = %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"result":[
+			{"sys_id":"d1","tablename":"incident","documentkey":"inc1","sys_created_on":"2024-01-15 11:00:00","sys_updated_on":"2024-01-15 11:00:00"}
+		]}`))
+	})
+
+	store, err := table.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	var got []string
+	cp, err := c.SyncDeletions(context.Background(), "incident", &DeletionsOptions{PageSize: 5, Store: store}, func(ds []Deletion) error {
+		for _, d := range ds {
+			got = append(got, d.SysID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("SyncDeletions() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "inc1" || cp.SysID != "d1" {
+		t.Fatalf("SyncDeletions() = %v, %+v", got, cp)
+	}
+
+	stored, ok, err := store.Load(context.Background(), "sys_audit_delete:incident")
+	if err != nil || !ok || stored != cp {
+		t.Fatalf("Load() = %+v, %v, %v; want %+v", stored, ok, err, cp)
+	}
+}
@@ -0,0 +1,183 @@
+package audit
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// Deletion is a record deleted from a table, as recorded in sys_audit_delete.
+type Deletion struct {
+	SysID        string // sys_id of the deleted record
+	Table        string
+	DisplayValue string
+	DeletedOn    time.Time
+	// Record holds the field values of the deleted record; nil when the
+	// instance stored no payload.
+	Record map[string]string
+
+	AuditSysID string // sys_id of the sys_audit_delete record
+}
+
+// DeletionsResponse is a page of deletions after a checkpoint.
+type DeletionsResponse struct {
+	Result []Deletion
+	Next   table.Checkpoint
+	More   bool
+}
+
+type deleteRecord struct {
+	SysID        string `json:"sys_id"`
+	TableName    string `json:"tablename"`
+	DocumentKey  string `json:"documentkey"`
+	DisplayValue string `json:"display_value"`
+	Payload      string `json:"payload"`
+	SysCreatedOn string `json:"sys_created_on"`
+}
+
+// Deletions returns the next page of records deleted from tableName after
+// since, oldest first. Only opts.PageSize is used from opts. sys_audit_delete
+// records are never updated, so the checkpoint works like the one of
+// table.Client.Changes; pass Next as since to read the following page.
+func (c *Client) Deletions(ctx context.Context, tableName string, since table.Checkpoint, opts *DeletionsOptions) (*DeletionsResponse, error) {
+	deletes, o, err := c.deletionsClient(tableName, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	page, err := deletes.Changes(ctx, since, o)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := toDeletions(page.Result)
+	if err != nil {
+		return nil, err
+	}
+	return &DeletionsResponse{Result: result, Next: page.Next, More: page.More}, nil
+}
+
+// SyncDeletions is table.Client.Sync for the deletions of tableName: it pages
+// through all deletions after the stored checkpoint, saving it after each page
+// handle accepts.
+func (c *Client) SyncDeletions(ctx context.Context, tableName string, opts *DeletionsOptions, handle func([]Deletion) error) (table.Checkpoint, error) {
+	deletes, o, err := c.deletionsClient(tableName, opts)
+	if err != nil {
+		return table.Checkpoint{}, err
+	}
+	if handle == nil {
+		return o.Since, table.ErrNilSyncHandler
+	}
+
+	return deletes.Sync(ctx, o, func(records []deleteRecord) error {
+		result, err := toDeletions(records)
+		if err != nil {
+			return err
+		}
+		return handle(result)
+	})
+}
+
+// deletionsClient returns a sys_audit_delete client and the sync options
+// selecting the deletions of tableName.
+func (c *Client) deletionsClient(tableName string, opts *DeletionsOptions) (*table.Client[deleteRecord], *table.SyncOptions, error) {
+	if c == nil || c.r == nil {
+		return nil, nil, table.ErrNilRequester
+	}
+
+	tableName = strings.TrimSpace(tableName)
+	if tableName == "" || strings.ContainsAny(tableName, `/\\`) {
+		return nil, nil, table.ErrInvalidTableName
+	}
+
+	query, err := table.NewQueryBuilder().Eq("tablename", tableName).Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	o := table.SyncOptions{Query: query, Key: "sys_audit_delete:" + tableName}
+	if opts != nil {
+		o.PageSize, o.Since, o.Store = opts.PageSize, opts.Since, opts.Store
+		if opts.Key != "" {
+			o.Key = opts.Key
+		}
+	}
+
+	deletes, err := table.New[deleteRecord](c.r, "sys_audit_delete", table.WithStructFields())
+	if err != nil {
+		return nil, nil, err
+	}
+	return deletes, &o, nil
+}
+
+func toDeletions(records []deleteRecord) ([]Deletion, error) {
+	out := make([]Deletion, 0, len(records))
+	for _, rec := range records {
+		deletedOn, err := time.Parse(table.DateTimeLayout, rec.SysCreatedOn)
+		if err != nil {
+			return nil, fmt.Errorf("parse sys_created_on of sys_audit_delete %s: %w", rec.SysID, err)
+		}
+		record, err := parseDeletePayload(rec.Payload)
+		if err != nil {
+			return nil, fmt.Errorf("parse payload of sys_audit_delete %s: %w", rec.SysID, err)
+		}
+		out = append(out, Deletion{
+			SysID:        rec.DocumentKey,
+			Table:        rec.TableName,
+			DisplayValue: rec.DisplayValue,
+			DeletedOn:    deletedOn,
+			Record:       record,
+			AuditSysID:   rec.SysID,
+		})
+	}
+	return out, nil
+}
+
+// parseDeletePayload reads the fields of the record in a sys_audit_delete
+// payload:
+//
+//	<record_update table="incident"><incident action="DELETE"><number>INC0010001</number>...</incident></record_update>
+func parseDeletePayload(payload string) (map[string]string, error) {
+	if strings.TrimSpace(payload) == "" {
+		return nil, nil
+	}
+
+	dec := xml.NewDecoder(strings.NewReader(payload))
+	record := map[string]string{}
+	depth := 0
+	var field string
+	var value strings.Builder
+
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			return record, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			depth++
+			if depth == 3 {
+				field = t.Name.Local
+				value.Reset()
+			}
+		case xml.CharData:
+			if depth == 3 {
+				value.Write(t)
+			}
+		case xml.EndElement:
+			if depth == 3 {
+				record[field] = value.String()
+			}
+			depth--
+		}
+	}
+}
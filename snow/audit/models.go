@@ -0,0 +1,53 @@
+package audit
+
+import "time"
+
+// Source is the table change events are read from.
+type Source string
+
+const (
+	// SourceAudit reads sys_audit, which stores raw (internal) values.
+	SourceAudit Source = "sys_audit"
+	// SourceHistory reads sys_history_line, which stores display values. History
+	// lines only exist once the history set of a record has been generated,
+	// e.g. by opening its history in the UI.
+	SourceHistory Source = "sys_history_line"
+	// SourceAuto reads sys_history_line and falls back to sys_audit when it
+	// has no lines for the record or is not readable.
+	SourceAuto Source = "auto"
+)
+
+// Event is a change of one field of a record.
+type Event struct {
+	SysID    string // sys_id of the sys_audit or sys_history_line record
+	Source   Source
+	Field    string
+	OldValue string
+	NewValue string
+	User     string
+	When     time.Time
+	// RecordCheckpoint is the sys_mod_count of the record after the change.
+	RecordCheckpoint int
+	Reason           string // sys_audit only
+}
+
+type auditRecord struct {
+	SysID            string `json:"sys_id"`
+	FieldName        string `json:"fieldname"`
+	OldValue         string `json:"oldvalue"`
+	NewValue         string `json:"newvalue"`
+	User             string `json:"user"`
+	SysCreatedOn     string `json:"sys_created_on"`
+	RecordCheckpoint string `json:"record_checkpoint"`
+	Reason           string `json:"reason"`
+}
+
+type historyLineRecord struct {
+	SysID      string `json:"sys_id"`
+	Field      string `json:"field"`
+	Old        string `json:"old"`
+	New        string `json:"new"`
+	UserName   string `json:"user_name"`
+	UpdateTime string `json:"update_time"`
+	Update     string `json:"update"`
+}
@@ -0,0 +1,30 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// HistoryOptions filters the events returned by History.
+type HistoryOptions struct {
+	Source Source   // Defaults to SourceAudit
+	Fields []string // Limit to these fields
+
+	Since time.Time // Only changes after Since
+	Until time.Time // Only changes at or before Until
+
+	PageSize int // Records per request; defaults to 1000
+}
+
+// DeletionsOptions configures Deletions and SyncDeletions.
+type DeletionsOptions struct {
+	PageSize int // Records per request; defaults to 1000
+
+	// Since is where SyncDeletions starts when Store has no checkpoint.
+	Since table.Checkpoint
+	// Store persists the checkpoint after every page handled by SyncDeletions.
+	Store table.CheckpointStore
+	// Key is the Store key; defaults to "sys_audit_delete:" + the table name.
+	Key string
+}
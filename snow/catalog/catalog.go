@@ -0,0 +1,146 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const catalogAPIPath = "/api/sn_sc/servicecatalog"
+
+// Client is a Service Catalog API client (/api/sn_sc/servicecatalog).
+type Client struct {
+	r snow.Requester
+}
+
+func New(r snow.Requester) (*Client, error) {
+	if r == nil {
+		return nil, table.ErrNilRequester
+	}
+	return &Client{r: r}, nil
+}
+
+func (c *Client) ListCatalogs(ctx context.Context, opts *ListOptions) ([]Catalog, error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out resultOne[[]Catalog]
+	if err := c.do(ctx, http.MethodGet, path.Join(catalogAPIPath, "catalogs"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
+
+func (c *Client) GetCatalog(ctx context.Context, sysID string) (*Catalog, error) {
+	p, err := idPath("catalogs", sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Catalog]
+	if err := c.do(ctx, http.MethodGet, p, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// ListCategories returns the categories of a catalog.
+func (c *Client) ListCategories(ctx context.Context, catalogSysID string, opts *ListOptions) ([]Category, error) {
+	p, err := idPath("catalogs", catalogSysID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out resultOne[[]Category]
+	if err := c.do(ctx, http.MethodGet, path.Join(p, "categories"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
+
+func (c *Client) GetCategory(ctx context.Context, sysID string) (*Category, error) {
+	p, err := idPath("categories", sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Category]
+	if err := c.do(ctx, http.MethodGet, p, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) ListItems(ctx context.Context, opts *ItemListOptions) ([]Item, error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out resultOne[[]Item]
+	if err := c.do(ctx, http.MethodGet, path.Join(catalogAPIPath, "items"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
+
+// GetItem returns a catalog item with its variable definitions.
+func (c *Client) GetItem(ctx context.Context, sysID string) (*Item, error) {
+	p, err := idPath("items", sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Item]
+	if err := c.do(ctx, http.MethodGet, p, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// ItemVariables returns the variable definitions of a catalog item, including
+// their types and choices.
+func (c *Client) ItemVariables(ctx context.Context, itemSysID string) ([]Variable, error) {
+	p, err := idPath("items", itemSysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[[]Variable]
+	if err := c.do(ctx, http.MethodGet, path.Join(p, "variables"), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
+
+func (c *Client) do(ctx context.Context, method, p string, q url.Values, body, out any) error {
+	if c == nil || c.r == nil {
+		return table.ErrNilRequester
+	}
+
+	req, err := c.r.NewRequest(ctx, method, p, q, body)
+	if err != nil {
+		return err
+	}
+	return c.r.Do(req, out)
+}
+
+func idPath(collection, sysID string) (string, error) {
+	sysID = strings.TrimSpace(sysID)
+	if sysID == "" || strings.ContainsAny(sysID, `/\\`) {
+		return "", table.ErrInvalidSysID
+	}
+	return path.Join(catalogAPIPath, collection, sysID), nil
+}
@@ -0,0 +1,204 @@
+package catalog
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/internal/snowtest"
+)
+
+const testItem = `{"result":{"sys_id":"item1","name":"Laptop","variables":[
+	{"name":"model","label":"Model","type":5,"mandatory":true,"choices":[{"index":0,"label":"13 inch","value":"13"},{"index":1,"label":"15 inch","value":"15"}]},
+	{"name":"needed_by","label":"Needed by","type":9},
+	{"name":"intro","label":"Intro","type":11},
+	{"name":"extras","type":19,"children":[{"name":"dock","label":"Dock","type":7}]}
+]}}`
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	c, err := New(snowtest.NewClient(t, h))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestOrderNow(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/sn_sc/servicecatalog/items/item1":
+			_, _ = w.Write([]byte(testItem))
+		case "POST /api/sn_sc/servicecatalog/items/item1/order_now":
+			var body struct {
+				Quantity  int               `json:"sysparm_quantity"`
+				Variables map[string]string `json:"variables"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			if body.Quantity != 1 || body.Variables["model"] != "15" || body.Variables["dock"] != "true" {
+				t.Errorf("body = %+v", body)
+			}
+			_, _ = w.Write([]byte(`{"result":{"sys_id":"req1","number":"REQ0010001","request_number":"REQ0010001","request_id":"req1","table":"sc_request"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	res, err := c.OrderNow(context.Background(), "item1", &OrderInput{
+		Variables: map[string]string{"model": "15", "dock": "true"},
+	})
+	if err != nil {
+		t.Fatalf("OrderNow() error = %v", err)
+	}
+	if res.RequestNumber != "REQ0010001" || res.RequestID != "req1" {
+		t.Fatalf("OrderNow() = %+v", res)
+	}
+}
+
+func TestOrderNowValidatesVariables(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(testItem))
+	})
+
+	_, err := c.OrderNow(context.Background(), "item1", &OrderInput{
+		Variables: map[string]string{"needed_by": "tomorrow", "colour": "red"},
+	})
+	if !errors.Is(err, ErrInvalidVariables) {
+		t.Fatalf("OrderNow() error = %v, want %v", err, ErrInvalidVariables)
+	}
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Problems) != 3 {
+		t.Fatalf("OrderNow() error = %v, want 3 problems", err)
+	}
+	want := []string{"colour", "model", "needed_by"}
+	for i, p := range verr.Problems {
+		if p.Name != want[i] {
+			t.Fatalf("problem %d = %+v, want %s", i, p, want[i])
+		}
+	}
+}
+
+func TestAddToCart(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /api/sn_sc/servicecatalog/items/item1":
+			_, _ = w.Write([]byte(testItem))
+		case "POST /api/sn_sc/servicecatalog/items/item1/add_to_cart":
+			var body struct {
+				Quantity  int               `json:"sysparm_quantity"`
+				Variables map[string]string `json:"variables"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+			}
+			if body.Quantity != 2 || body.Variables["model"] != "13" {
+				t.Errorf("body = %+v", body)
+			}
+			_, _ = w.Write([]byte(`{"result":{"cart_id":"cart1","subtotal":"$2,000.00","items":[{"cart_item_id":"ci1","catalog_item_id":"item1","item_name":"Laptop","quantity":"2"}]}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+
+	cart, err := c.AddToCart(context.Background(), "item1", &OrderInput{Quantity: 2, Variables: map[string]string{"model": "13"}})
+	if err != nil {
+		t.Fatalf("AddToCart() error = %v", err)
+	}
+	if cart.CartID != "cart1" || len(cart.Items) != 1 || cart.Items[0].CartItemID != "ci1" || cart.Items[0].Quantity != "2" {
+		t.Fatalf("AddToCart() = %+v", cart)
+	}
+}
+
+func TestUpdateCartItem(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/api/sn_sc/servicecatalog/cart/ci1" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if body["sysparm_quantity"] != float64(3) || body["sysparm_requested_for"] != "u1" {
+			t.Errorf("body = %v", body)
+		}
+		_, _ = w.Write([]byte(`{"result":{"cart_id":"cart1","items":[{"cart_item_id":"ci1","quantity":"3"}]}}`))
+	})
+
+	// Variables are not validated, so no item lookup is expected.
+	cart, err := c.UpdateCartItem(context.Background(), "ci1", &OrderInput{Quantity: 3, RequestedFor: "u1"})
+	if err != nil {
+		t.Fatalf("UpdateCartItem() error = %v", err)
+	}
+	if len(cart.Items) != 1 || cart.Items[0].Quantity != "3" {
+		t.Fatalf("UpdateCartItem() = %+v", cart)
+	}
+
+	if _, err := c.UpdateCartItem(context.Background(), "../ci1", nil); err == nil {
+		t.Fatal("UpdateCartItem() with a path in the id succeeded")
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		call func(*Client, context.Context) (*OrderResult, error)
+	}{
+		{"Checkout", "/api/sn_sc/servicecatalog/cart/checkout", (*Client).Checkout},
+		{"SubmitOrder", "/api/sn_sc/servicecatalog/cart/submit_order", (*Client).SubmitOrder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != tt.path {
+					t.Errorf("request = %s %s", r.Method, r.URL.Path)
+				}
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body) != 0 {
+					t.Errorf("body = %v, %v, want {}", body, err)
+				}
+				_, _ = w.Write([]byte(`{"result":{"request_id":"req1","request_number":"REQ0010002"}}`))
+			})
+
+			res, err := tt.call(c, context.Background())
+			if err != nil {
+				t.Fatalf("%s() error = %v", tt.name, err)
+			}
+			if res.RequestID != "req1" || res.RequestNumber != "REQ0010002" {
+				t.Fatalf("%s() = %+v", tt.name, res)
+			}
+		})
+	}
+}
+
+func TestRequestedItems(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/api/now/table/sc_req_item" ||
+			q.Get("sysparm_query") != "request=req1^ORDERBYnumber" || q.Get("sysparm_exclude_reference_link") != "true" {
+			t.Errorf("request = %s %s?%s", r.Method, r.URL.Path, r.URL.RawQuery)
+		}
+		if fields := q.Get("sysparm_fields"); fields != "sys_id,number,state,stage,cat_item,request,approval" {
+			t.Errorf("sysparm_fields = %q", fields)
+		}
+		_, _ = w.Write([]byte(`{"result":[{"sys_id":"ritm1","number":"RITM0010001","cat_item":"item1","request":"req1"},{"sys_id":"ritm2","number":"RITM0010002","request":"req1"}]}`))
+	})
+
+	items, err := c.RequestedItems(context.Background(), "req1")
+	if err != nil {
+		t.Fatalf("RequestedItems() error = %v", err)
+	}
+	if len(items) != 2 || items[0].Number != "RITM0010001" || items[0].CatItem != "item1" || items[1].SysID != "ritm2" {
+		t.Fatalf("RequestedItems() = %+v", items)
+	}
+}
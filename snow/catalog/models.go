@@ -0,0 +1,185 @@
+package catalog
+
+// Catalog is a service catalog (sc_catalog).
+type Catalog struct {
+	SysID         string `json:"sys_id"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	HasCategories bool   `json:"has_categories"`
+	HasItems      bool   `json:"has_items"`
+}
+
+// Category is a catalog category (sc_category).
+type Category struct {
+	SysID           string `json:"sys_id"`
+	Title           string `json:"title"`
+	Description     string `json:"description"`
+	FullDescription string `json:"full_description"`
+	Count           int    `json:"count"`
+}
+
+// ItemCategory is the category summary embedded in an item.
+type ItemCategory struct {
+	SysID string `json:"sys_id"`
+	Title string `json:"title"`
+}
+
+// Item is a catalog item. Variables are only returned by GetItem.
+type Item struct {
+	SysID            string       `json:"sys_id"`
+	Name             string       `json:"name"`
+	ShortDescription string       `json:"short_description"`
+	Description      string       `json:"description"`
+	Category         ItemCategory `json:"category"`
+	Type             string       `json:"type"`
+	Price            string       `json:"price"`
+	Variables        []Variable   `json:"variables,omitempty"`
+}
+
+// VariableType is the numeric type of a catalog variable (item_option_new.type).
+type VariableType int
+
+const (
+	VariableYesNo              VariableType = 1
+	VariableMultiLineText      VariableType = 2
+	VariableMultipleChoice     VariableType = 3
+	VariableNumericScale       VariableType = 4
+	VariableSelectBox          VariableType = 5
+	VariableSingleLineText     VariableType = 6
+	VariableCheckBox           VariableType = 7
+	VariableReference          VariableType = 8
+	VariableDate               VariableType = 9
+	VariableDateTime           VariableType = 10
+	VariableLabel              VariableType = 11
+	VariableBreak              VariableType = 12
+	VariableMacro              VariableType = 14
+	VariableUIPage             VariableType = 15
+	VariableWideSingleLineText VariableType = 16
+	VariableMacroWithLabel     VariableType = 17
+	VariableLookupSelectBox    VariableType = 18
+	VariableContainerStart     VariableType = 19
+	VariableContainerEnd       VariableType = 20
+	VariableListCollector      VariableType = 21
+	VariableLookupMultiple     VariableType = 22
+	VariableHTML               VariableType = 23
+	VariableContainerSplit     VariableType = 24
+	VariableMasked             VariableType = 25
+	VariableEmail              VariableType = 26
+	VariableURL                VariableType = 27
+	VariableIPAddress          VariableType = 28
+	VariableDuration           VariableType = 29
+	VariableRequestedFor       VariableType = 31
+	VariableRichTextLabel      VariableType = 32
+	VariableAttachment         VariableType = 33
+)
+
+// IsInput reports whether variables of this type take a value. Layout types
+// such as labels and containers do not.
+func (t VariableType) IsInput() bool {
+	switch t {
+	case VariableLabel, VariableBreak, VariableMacro, VariableUIPage, VariableMacroWithLabel,
+		VariableContainerStart, VariableContainerEnd, VariableContainerSplit, VariableRichTextLabel:
+		return false
+	default:
+		return true
+	}
+}
+
+// Variable is a catalog item variable definition.
+type Variable struct {
+	Name         string       `json:"name"`
+	Label        string       `json:"label"`
+	Type         VariableType `json:"type"`
+	FriendlyType string       `json:"friendly_type"`
+	Mandatory    bool         `json:"mandatory"`
+	ReadOnly     bool         `json:"read_only"`
+	DisplayValue string       `json:"displayvalue"`
+	Value        any          `json:"value"`
+	Choices      []Choice     `json:"choices,omitempty"`
+	Children     []Variable   `json:"children,omitempty"`
+}
+
+// Choice is a choice of a select box or multiple choice variable.
+type Choice struct {
+	Index int    `json:"index"`
+	Label string `json:"label"`
+	Value string `json:"value"`
+	Price string `json:"price,omitempty"`
+}
+
+// OrderInput is the quantity and variable values of an order or cart item.
+// Variable values are sent as strings, the way the catalog UI submits them.
+type OrderInput struct {
+	Quantity     int // defaults to 1
+	Variables    map[string]string
+	RequestedFor string // sys_user sys_id; defaults to the calling user
+
+	// SkipValidation sends the variables without checking them against the
+	// item definition first.
+	SkipValidation bool
+}
+
+func (in *OrderInput) body() map[string]any {
+	quantity := 1
+	variables := map[string]string{}
+	requestedFor := ""
+	if in != nil {
+		if in.Quantity > 0 {
+			quantity = in.Quantity
+		}
+		if in.Variables != nil {
+			variables = in.Variables
+		}
+		requestedFor = in.RequestedFor
+	}
+
+	body := map[string]any{
+		"sysparm_quantity": quantity,
+		"variables":        variables,
+	}
+	if requestedFor != "" {
+		body["sysparm_requested_for"] = requestedFor
+	}
+	return body
+}
+
+// OrderResult identifies the request created by an order.
+type OrderResult struct {
+	SysID         string `json:"sys_id"`
+	Number        string `json:"number"`
+	RequestID     string `json:"request_id"`
+	RequestNumber string `json:"request_number"`
+	Table         string `json:"table"`
+}
+
+// Cart is the current user's cart.
+type Cart struct {
+	CartID   string     `json:"cart_id"`
+	Subtotal string     `json:"subtotal"`
+	Items    []CartItem `json:"items"`
+}
+
+// CartItem is an item in the cart.
+type CartItem struct {
+	CartItemID string `json:"cart_item_id"`
+	CatalogID  string `json:"catalog_item_id"`
+	ItemName   string `json:"item_name"`
+	Quantity   string `json:"quantity"`
+	Price      string `json:"price"`
+}
+
+// RequestedItem is an sc_req_item (RITM) created by an order.
+type RequestedItem struct {
+	SysID    string `json:"sys_id"`
+	Number   string `json:"number"`
+	State    string `json:"state"`
+	Stage    string `json:"stage"`
+	CatItem  string `json:"cat_item"`
+	Request  string `json:"request"`
+	Approval string `json:"approval"`
+}
+
+// Internal envelope types matching the ServiceNow result wrapper.
+type resultOne[T any] struct {
+	Result T `json:"result"`
+}
@@ -0,0 +1,67 @@
+package catalog
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// ListOptions pages catalog, category and item lists.
+type ListOptions struct {
+	Text   string // Free text search
+	Limit  *int
+	Offset *int
+}
+
+// ItemListOptions filters catalog items.
+type ItemListOptions struct {
+	ListOptions
+
+	Catalog  string // sc_catalog sys_id
+	Category string // sc_category sys_id
+}
+
+func (o *ListOptions) apply(q url.Values) error {
+	if o == nil {
+		return nil
+	}
+
+	if o.Limit != nil && *o.Limit < 0 {
+		return table.ErrInvalidLimit
+	}
+	if o.Offset != nil && *o.Offset < 0 {
+		return table.ErrInvalidOffset
+	}
+
+	if text := strings.TrimSpace(o.Text); text != "" {
+		q.Set("sysparm_text", text)
+	}
+	if o.Limit != nil {
+		q.Set("sysparm_limit", strconv.Itoa(*o.Limit))
+	}
+	if o.Offset != nil {
+		q.Set("sysparm_offset", strconv.Itoa(*o.Offset))
+	}
+
+	return nil
+}
+
+func (o *ItemListOptions) apply(q url.Values) error {
+	if o == nil {
+		return nil
+	}
+
+	if err := o.ListOptions.apply(q); err != nil {
+		return err
+	}
+	if catalog := strings.TrimSpace(o.Catalog); catalog != "" {
+		q.Set("sysparm_catalog", catalog)
+	}
+	if category := strings.TrimSpace(o.Category); category != "" {
+		q.Set("sysparm_category", category)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package catalog
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// OrderNow orders a catalog item directly, bypassing the cart. Unless
+// in.SkipValidation is set, the variables are validated against the item
+// definition first and a *ValidationError is returned if they do not match.
+func (c *Client) OrderNow(ctx context.Context, itemSysID string, in *OrderInput) (*OrderResult, error) {
+	p, err := idPath("items", itemSysID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateOrder(ctx, itemSysID, in); err != nil {
+		return nil, err
+	}
+
+	var out resultOne[OrderResult]
+	if err := c.do(ctx, http.MethodPost, path.Join(p, "order_now"), nil, in.body(), &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// AddToCart adds a catalog item to the current user's cart, validating the
+// variables like OrderNow.
+func (c *Client) AddToCart(ctx context.Context, itemSysID string, in *OrderInput) (*Cart, error) {
+	p, err := idPath("items", itemSysID)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.validateOrder(ctx, itemSysID, in); err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Cart]
+	if err := c.do(ctx, http.MethodPost, path.Join(p, "add_to_cart"), nil, in.body(), &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// UpdateCartItem changes the quantity and variables of a cart item. The cart
+// item does not identify its catalog item, so variables are not validated.
+func (c *Client) UpdateCartItem(ctx context.Context, cartItemID string, in *OrderInput) (*Cart, error) {
+	p, err := cartPath(cartItemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Cart]
+	if err := c.do(ctx, http.MethodPut, p, nil, in.body(), &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) RemoveCartItem(ctx context.Context, cartItemID string) error {
+	p, err := cartPath(cartItemID)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, p, nil, nil, nil)
+}
+
+// Cart returns the current user's cart.
+func (c *Client) Cart(ctx context.Context) (*Cart, error) {
+	var out resultOne[Cart]
+	if err := c.do(ctx, http.MethodGet, path.Join(catalogAPIPath, "cart"), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// EmptyCart removes every item from the cart cartID.
+func (c *Client) EmptyCart(ctx context.Context, cartID string) error {
+	p, err := cartPath(cartID)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, path.Join(p, "empty"), nil, nil, nil)
+}
+
+// Checkout checks out the cart. With two-step checkout enabled the order is
+// only placed by SubmitOrder; otherwise the result already identifies the request.
+func (c *Client) Checkout(ctx context.Context) (*OrderResult, error) {
+	var out resultOne[OrderResult]
+	if err := c.do(ctx, http.MethodPost, path.Join(catalogAPIPath, "cart", "checkout"), nil, map[string]any{}, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// SubmitOrder places the order for the cart (two-step checkout).
+func (c *Client) SubmitOrder(ctx context.Context) (*OrderResult, error) {
+	var out resultOne[OrderResult]
+	if err := c.do(ctx, http.MethodPost, path.Join(catalogAPIPath, "cart", "submit_order"), nil, map[string]any{}, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// RequestedItems returns the RITMs of the request requestSysID (see
+// OrderResult.RequestID), ordered by number.
+func (c *Client) RequestedItems(ctx context.Context, requestSysID string) ([]RequestedItem, error) {
+	if c == nil || c.r == nil {
+		return nil, table.ErrNilRequester
+	}
+
+	requestSysID = strings.TrimSpace(requestSysID)
+	if requestSysID == "" {
+		return nil, table.ErrInvalidSysID
+	}
+
+	items, err := table.New[RequestedItem](c.r, "sc_req_item", table.WithStructFields())
+	if err != nil {
+		return nil, err
+	}
+
+	query, err := table.NewQueryBuilder().Eq("request", requestSysID).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := items.List(ctx, &table.ListOptions{
+		Query:                query + "^ORDERBYnumber",
+		ExcludeReferenceLink: table.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Result, nil
+}
+
+func (c *Client) validateOrder(ctx context.Context, itemSysID string, in *OrderInput) error {
+	if in != nil && in.SkipValidation {
+		return nil
+	}
+
+	item, err := c.GetItem(ctx, itemSysID)
+	if err != nil {
+		return err
+	}
+
+	var values map[string]string
+	if in != nil {
+		values = in.Variables
+	}
+	return ValidateVariables(item.Variables, values)
+}
+
+func cartPath(id string) (string, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\\`) {
+		return "", table.ErrInvalidSysID
+	}
+	return path.Join(catalogAPIPath, "cart", id), nil
+}
@@ -0,0 +1,151 @@
+package catalog
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+var ErrInvalidVariables = errors.New("invalid catalog variables")
+
+// VariableProblem describes why a variable value was rejected.
+type VariableProblem struct {
+	Name    string
+	Problem string
+}
+
+// ValidationError lists every problem found by ValidateVariables. It matches
+// ErrInvalidVariables with errors.Is.
+type ValidationError struct {
+	Problems []VariableProblem
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		parts = append(parts, p.Name+": "+p.Problem)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidVariables, strings.Join(parts, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool { return target == ErrInvalidVariables }
+
+// ValidateVariables checks values against variable definitions: every value
+// must belong to a known input variable, mandatory variables must be set,
+// choice variables must use one of their choices, and typed variables
+// (yes/no, check box, numbers, dates, e-mail, URL, IP address) must parse.
+func ValidateVariables(defs []Variable, values map[string]string) error {
+	byName := map[string]Variable{}
+	flattenVariables(defs, byName)
+
+	var problems []VariableProblem
+	add := func(name, format string, args ...any) {
+		problems = append(problems, VariableProblem{Name: name, Problem: fmt.Sprintf(format, args...)})
+	}
+
+	for name, value := range values {
+		def, ok := byName[name]
+		if !ok {
+			add(name, "unknown variable")
+			continue
+		}
+		if def.ReadOnly {
+			add(name, "variable is read-only")
+			continue
+		}
+		if value == "" {
+			continue
+		}
+		if problem := checkVariableValue(def, value); problem != "" {
+			add(name, "%s", problem)
+		}
+	}
+
+	for name, def := range byName {
+		if def.Mandatory && strings.TrimSpace(values[name]) == "" && !hasDefault(def) {
+			add(name, "mandatory variable is missing")
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	sort.Slice(problems, func(i, k int) bool { return problems[i].Name < problems[k].Name })
+	return &ValidationError{Problems: problems}
+}
+
+func flattenVariables(defs []Variable, out map[string]Variable) {
+	for _, def := range defs {
+		if def.Type.IsInput() && def.Name != "" {
+			out[def.Name] = def
+		}
+		flattenVariables(def.Children, out)
+	}
+}
+
+func hasDefault(def Variable) bool {
+	switch v := def.Value.(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	default:
+		return true
+	}
+}
+
+func checkVariableValue(def Variable, value string) string {
+	if len(def.Choices) > 0 && (def.Type == VariableSelectBox || def.Type == VariableMultipleChoice) {
+		for _, choice := range def.Choices {
+			if choice.Value == value {
+				return ""
+			}
+		}
+		return fmt.Sprintf("%q is not one of its choices", value)
+	}
+
+	switch def.Type {
+	case VariableYesNo:
+		if value != "Yes" && value != "No" && value != "yes" && value != "no" {
+			return "must be Yes or No"
+		}
+	case VariableCheckBox:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return "must be true or false"
+		}
+	case VariableNumericScale:
+		if _, err := strconv.Atoi(value); err != nil {
+			return "must be a number"
+		}
+	case VariableDate:
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			return "must be a date (2006-01-02)"
+		}
+	case VariableDateTime:
+		if _, err := time.Parse(table.DateTimeLayout, value); err != nil {
+			return "must be a date and time (" + table.DateTimeLayout + ")"
+		}
+	case VariableEmail:
+		if !strings.Contains(value, "@") {
+			return "must be an e-mail address"
+		}
+	case VariableURL:
+		if u, err := url.Parse(value); err != nil || u.Scheme == "" {
+			return "must be an absolute URL"
+		}
+	case VariableIPAddress:
+		if net.ParseIP(value) == nil {
+			return "must be an IP address"
+		}
+	}
+
+	return ""
+}
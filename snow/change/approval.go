@@ -0,0 +1,57 @@
+package change
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path"
+)
+
+var ErrInvalidApprovalState = errors.New("approval state must be 'approved' or 'rejected'")
+
+// Approve approves the change for the current user.
+func (c *Client) Approve(ctx context.Context, sysID, comments string) (*Change, error) {
+	return c.SetApproval(ctx, sysID, ApprovalApproved, comments)
+}
+
+// Reject rejects the change for the current user.
+func (c *Client) Reject(ctx context.Context, sysID, comments string) (*Change, error) {
+	return c.SetApproval(ctx, sysID, ApprovalRejected, comments)
+}
+
+// SetApproval records the current user's approval decision on a change.
+func (c *Client) SetApproval(ctx context.Context, sysID string, state ApprovalState, comments string) (*Change, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+	if state != ApprovalApproved && state != ApprovalRejected {
+		return nil, ErrInvalidApprovalState
+	}
+
+	body := map[string]string{"state": string(state)}
+	if comments != "" {
+		body["comments"] = comments
+	}
+
+	var out resultOne[Change]
+	if err := c.do(ctx, http.MethodPatch, path.Join(p, "approvals"), nil, body, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// CalculateRisk runs the risk assessment of a change and returns the change
+// with its updated risk and impact.
+func (c *Client) CalculateRisk(ctx context.Context, sysID string) (*Change, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Change]
+	if err := c.do(ctx, http.MethodPatch, path.Join(p, "risk"), nil, map[string]any{}, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
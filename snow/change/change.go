@@ -0,0 +1,218 @@
+package change
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const changeAPIPath = "/api/sn_chg_rest/change"
+
+var (
+	ErrInvalidTemplateID = errors.New("invalid standard change template id")
+	ErrTemplateNotFound  = errors.New("standard change template not found")
+	ErrStateNotAllowed   = errors.New("state is not an available next state")
+	ErrEmptyState        = errors.New("state cannot be empty")
+)
+
+// Client is a Change Management API client (/api/sn_chg_rest/change).
+type Client struct {
+	r snow.Requester
+}
+
+func New(r snow.Requester) (*Client, error) {
+	if r == nil {
+		return nil, table.ErrNilRequester
+	}
+	return &Client{r: r}, nil
+}
+
+// List returns change requests of any type.
+func (c *Client) List(ctx context.Context, opts *ListOptions) (*ListResponse[Change], error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out ListResponse[Change]
+	if err := c.do(ctx, http.MethodGet, changeAPIPath, q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) Get(ctx context.Context, sysID string) (*Change, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Change]
+	if err := c.do(ctx, http.MethodGet, p, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// CreateNormal creates a normal change with the given field values.
+func (c *Client) CreateNormal(ctx context.Context, fields map[string]any) (*Change, error) {
+	return c.create(ctx, path.Join(changeAPIPath, string(TypeNormal)), fields)
+}
+
+// CreateEmergency creates an emergency change with the given field values.
+func (c *Client) CreateEmergency(ctx context.Context, fields map[string]any) (*Change, error) {
+	return c.create(ctx, path.Join(changeAPIPath, string(TypeEmergency)), fields)
+}
+
+// CreateStandard creates a standard change from a template. fields override
+// the template values.
+func (c *Client) CreateStandard(ctx context.Context, templateID string, fields map[string]any) (*Change, error) {
+	templateID = strings.TrimSpace(templateID)
+	if templateID == "" || strings.ContainsAny(templateID, `/\\`) {
+		return nil, ErrInvalidTemplateID
+	}
+	return c.create(ctx, path.Join(changeAPIPath, string(TypeStandard), templateID), fields)
+}
+
+func (c *Client) Update(ctx context.Context, sysID string, fields map[string]any) (*Change, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, table.ErrNilInput
+	}
+
+	var out resultOne[Change]
+	if err := c.do(ctx, http.MethodPatch, p, nil, fields, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) Delete(ctx context.Context, sysID string) error {
+	p, err := changePath(sysID)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, p, nil, nil, nil)
+}
+
+// ListStandardTemplates returns the standard change templates matching opts.
+func (c *Client) ListStandardTemplates(ctx context.Context, opts *ListOptions) (*ListResponse[StandardTemplate], error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out ListResponse[StandardTemplate]
+	if err := c.do(ctx, http.MethodGet, path.Join(changeAPIPath, "standard", "template"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) GetStandardTemplate(ctx context.Context, templateID string) (*StandardTemplate, error) {
+	templateID = strings.TrimSpace(templateID)
+	if templateID == "" || strings.ContainsAny(templateID, `/\\`) {
+		return nil, ErrInvalidTemplateID
+	}
+
+	var out resultOne[StandardTemplate]
+	if err := c.do(ctx, http.MethodGet, path.Join(changeAPIPath, "standard", "template", templateID), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// FindStandardTemplate returns the active standard change template named name.
+func (c *Client) FindStandardTemplate(ctx context.Context, name string) (*StandardTemplate, error) {
+	query, err := table.NewQueryBuilder().
+		Eq("name", name).
+		Eq("active", true).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.ListStandardTemplates(ctx, &ListOptions{Query: query, Limit: table.Int(1)})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Result) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, name)
+	}
+	return &list.Result[0], nil
+}
+
+// NextStates returns the states the change can move to from its current state.
+func (c *Client) NextStates(ctx context.Context, sysID string) (*NextStates, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[NextStates]
+	if err := c.do(ctx, http.MethodGet, path.Join(p, "nextstates"), nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+// Transition moves the change to state, after checking that state is one of
+// its available next states. state is the state value, e.g. "-2" (Scheduled).
+func (c *Client) Transition(ctx context.Context, sysID, state string) (*Change, error) {
+	state = strings.TrimSpace(state)
+	if state == "" {
+		return nil, ErrEmptyState
+	}
+
+	next, err := c.NextStates(ctx, sysID)
+	if err != nil {
+		return nil, err
+	}
+	if !next.Allows(state) {
+		return nil, fmt.Errorf("%w: %s (available: %s)", ErrStateNotAllowed, state, strings.Join(next.AvailableStates, ","))
+	}
+
+	return c.Update(ctx, sysID, map[string]any{"state": state})
+}
+
+func (c *Client) create(ctx context.Context, p string, fields map[string]any) (*Change, error) {
+	if fields == nil {
+		fields = map[string]any{}
+	}
+
+	var out resultOne[Change]
+	if err := c.do(ctx, http.MethodPost, p, nil, fields, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) do(ctx context.Context, method, p string, q url.Values, body, out any) error {
+	if c == nil || c.r == nil {
+		return table.ErrNilRequester
+	}
+
+	req, err := c.r.NewRequest(ctx, method, p, q, body)
+	if err != nil {
+		return err
+	}
+	return c.r.Do(req, out)
+}
+
+func changePath(sysID string) (string, error) {
+	sysID = strings.TrimSpace(sysID)
+	if sysID == "" || strings.ContainsAny(sysID, `/\\`) {
+		return "", table.ErrInvalidSysID
+	}
+	return path.Join(changeAPIPath, sysID), nil
+}
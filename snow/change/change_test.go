@@ -0,0 +1,217 @@
+package change
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/internal/snowtest"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	c, err := New(snowtest.NewClient(t, h))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestCreateStandard(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/sn_chg_rest/change/standard/tmpl1" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if body["short_description"] != "Deploy v2" {
+			t.Errorf("body = %v", body)
+		}
+
+		_, _ = w.Write([]byte(`{"result":{"sys_id":{"value":"chg1","display_value":"chg1"},"number":{"value":"CHG0030001","display_value":"CHG0030001"},"state":{"value":"-2","display_value":"Scheduled"},"u_custom":{"value":"x","display_value":"X"}}}`))
+	})
+
+	chg, err := c.CreateStandard(context.Background(), "tmpl1", map[string]any{"short_description": "Deploy v2"})
+	if err != nil {
+		t.Fatalf("CreateStandard() error = %v", err)
+	}
+	if chg.Number.Value != "CHG0030001" || chg.State.DisplayValue != "Scheduled" || chg.Fields["u_custom"].DisplayValue != "X" {
+		t.Fatalf("CreateStandard() = %+v", chg)
+	}
+}
+
+func TestTransitionRejectsUnavailableState(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/api/sn_chg_rest/change/chg1/nextstates" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"result":{"available_states":["-5","-4"],"state_label":{"-5":"New","-4":"Assess"}}}`))
+	})
+
+	_, err := c.Transition(context.Background(), "chg1", "-1")
+	if !errors.Is(err, ErrStateNotAllowed) {
+		t.Fatalf("Transition() error = %v, want %v", err, ErrStateNotAllowed)
+	}
+}
+
+func TestDetectConflicts(t *testing.T) {
+	var started, polls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/sn_chg_rest/change/chg1/conflict" {
+			t.Errorf("path = %s", r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			started.Add(1)
+			_, _ = w.Write([]byte(`{"result":{"status":{"state":"Requested"}}}`))
+		case http.MethodGet:
+			if polls.Add(1) < 3 {
+				_, _ = w.Write([]byte(`{"result":[],"status":{"state":"Executing"}}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"result":[{"sys_id":{"value":"cf1","display_value":"cf1"},"type":{"value":"blackout","display_value":"Blackout"}}],"status":{"state":"Completed"}}`))
+		default:
+			t.Errorf("method = %s", r.Method)
+		}
+	})
+
+	res, err := c.DetectConflicts(context.Background(), "chg1", time.Millisecond)
+	if err != nil {
+		t.Fatalf("DetectConflicts() error = %v", err)
+	}
+	if started.Load() != 1 || polls.Load() != 3 {
+		t.Errorf("started = %d, polls = %d, want 1 and 3", started.Load(), polls.Load())
+	}
+	if res.Status.State != ConflictStateCompleted || len(res.Conflicts) != 1 || res.Conflicts[0].Type.DisplayValue != "Blackout" {
+		t.Fatalf("DetectConflicts() = %+v", res)
+	}
+}
+
+func TestSetApproval(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/sn_chg_rest/change/chg1/approvals" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+			return
+		}
+		if len(body) != 2 || body["state"] != "rejected" || body["comments"] != "Missing backout plan" {
+			t.Errorf("body = %v", body)
+		}
+
+		_, _ = w.Write([]byte(`{"result":{"sys_id":{"value":"chg1","display_value":"chg1"},"approval":{"value":"rejected","display_value":"Rejected"}}}`))
+	})
+
+	chg, err := c.Reject(context.Background(), "chg1", "Missing backout plan")
+	if err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if chg.Approval.Value != "rejected" {
+		t.Fatalf("Reject() = %+v", chg)
+	}
+
+	if _, err := c.SetApproval(context.Background(), "chg1", "requested", ""); !errors.Is(err, ErrInvalidApprovalState) {
+		t.Fatalf("SetApproval() error = %v, want %v", err, ErrInvalidApprovalState)
+	}
+}
+
+func TestCalculateRisk(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/sn_chg_rest/change/chg1/risk" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"result":{"sys_id":{"value":"chg1","display_value":"chg1"},"risk":{"value":"2","display_value":"High"},"impact":{"value":"1","display_value":"1 - High"}}}`))
+	})
+
+	chg, err := c.CalculateRisk(context.Background(), "chg1")
+	if err != nil {
+		t.Fatalf("CalculateRisk() error = %v", err)
+	}
+	if chg.Risk.DisplayValue != "High" || chg.Impact.Value != "1" {
+		t.Fatalf("CalculateRisk() = %+v", chg)
+	}
+}
+
+func TestTasks(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/sn_chg_rest/change/chg1/task" {
+			t.Errorf("path = %s", r.URL.Path)
+			return
+		}
+		switch r.Method {
+		case http.MethodPost:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+				return
+			}
+			if body["short_description"] != "Run smoke tests" {
+				t.Errorf("body = %v", body)
+			}
+			_, _ = w.Write([]byte(`{"result":{"sys_id":{"value":"ctask1","display_value":"ctask1"},"number":{"value":"CTASK0010001","display_value":"CTASK0010001"}}}`))
+		case http.MethodGet:
+			if got := r.URL.Query().Get("sysparm_query"); got != "state=1" {
+				t.Errorf("sysparm_query = %q", got)
+			}
+			_, _ = w.Write([]byte(`{"result":[{"sys_id":{"value":"ctask1","display_value":"ctask1"},"state":{"value":"1","display_value":"Open"}}]}`))
+		default:
+			t.Errorf("method = %s", r.Method)
+		}
+	})
+
+	task, err := c.CreateTask(context.Background(), "chg1", map[string]any{"short_description": "Run smoke tests"})
+	if err != nil {
+		t.Fatalf("CreateTask() error = %v", err)
+	}
+	if task.Number.Value != "CTASK0010001" {
+		t.Fatalf("CreateTask() = %+v", task)
+	}
+
+	tasks, err := c.ListTasks(context.Background(), "chg1", &ListOptions{Query: "state=1"})
+	if err != nil {
+		t.Fatalf("ListTasks() error = %v", err)
+	}
+	if len(tasks.Result) != 1 || tasks.Result[0].State.DisplayValue != "Open" {
+		t.Fatalf("ListTasks() = %+v", tasks)
+	}
+}
+
+func TestAssociateCIs(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/sn_chg_rest/change/chg1/ci" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+			return
+		}
+		if body["cmdb_ci_sys_ids"] != "ci1,ci2" || body["association_type"] != "affected" {
+			t.Errorf("body = %v", body)
+		}
+		_, _ = w.Write([]byte(`{"result":{}}`))
+	})
+
+	if err := c.AssociateCIs(context.Background(), "chg1", AssociationAffected, "ci1", " ", "ci2"); err != nil {
+		t.Fatalf("AssociateCIs() error = %v", err)
+	}
+	if err := c.AssociateCIs(context.Background(), "chg1", AssociationAffected, ""); !errors.Is(err, ErrEmptyCIList) {
+		t.Fatalf("AssociateCIs() error = %v, want %v", err, ErrEmptyCIList)
+	}
+	if err := c.AssociateCIs(context.Background(), "chg1", "related", "ci1"); !errors.Is(err, ErrInvalidAssociationType) {
+		t.Fatalf("AssociateCIs() error = %v, want %v", err, ErrInvalidAssociationType)
+	}
+}
@@ -0,0 +1,102 @@
+package change
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"path"
+	"time"
+)
+
+const defaultConflictPollInterval = 2 * time.Second
+
+// StartConflictCheck starts conflict detection for a change.
+func (c *Client) StartConflictCheck(ctx context.Context, sysID string) (*ConflictStatus, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[ConflictResult]
+	if err := c.do(ctx, http.MethodPost, path.Join(p, "conflict"), nil, map[string]any{}, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result.Status, nil
+}
+
+// Conflicts returns the state of the last conflict detection run and the
+// conflicts it found.
+func (c *Client) Conflicts(ctx context.Context, sysID string) (*ConflictResult, error) {
+	p, err := changePath(sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out struct {
+		Result json.RawMessage `json:"result"`
+		Status ConflictStatus  `json:"status"`
+	}
+	if err := c.do(ctx, http.MethodGet, path.Join(p, "conflict"), nil, nil, &out); err != nil {
+		return nil, err
+	}
+
+	// Depending on the release, conflicts come back as the result array with
+	// a top-level status, or as a result object holding both.
+	res := &ConflictResult{}
+	raw := bytes.TrimSpace(out.Result)
+	switch {
+	case len(raw) > 0 && raw[0] == '[':
+		if err := json.Unmarshal(raw, &res.Conflicts); err != nil {
+			return nil, err
+		}
+	case len(raw) > 0 && raw[0] == '{':
+		if err := json.Unmarshal(raw, res); err != nil {
+			return nil, err
+		}
+	}
+	if res.Status.State == "" {
+		res.Status = out.Status
+	}
+	return res, nil
+}
+
+// CancelConflictCheck cancels a running conflict detection.
+func (c *Client) CancelConflictCheck(ctx context.Context, sysID string) error {
+	p, err := changePath(sysID)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, path.Join(p, "conflict"), nil, nil, nil)
+}
+
+// DetectConflicts starts conflict detection and polls every interval until it
+// finishes or ctx is done. interval defaults to 2s.
+func (c *Client) DetectConflicts(ctx context.Context, sysID string, interval time.Duration) (*ConflictResult, error) {
+	if interval <= 0 {
+		interval = defaultConflictPollInterval
+	}
+
+	if _, err := c.StartConflictCheck(ctx, sysID); err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := c.Conflicts(ctx, sysID)
+		if err != nil {
+			return nil, err
+		}
+		if res.Status.State.Done() {
+			return res, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package change
+
+import (
+	"encoding/json"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// Type is a change request type.
+type Type string
+
+const (
+	TypeNormal    Type = "normal"
+	TypeStandard  Type = "standard"
+	TypeEmergency Type = "emergency"
+)
+
+// Change is a change_request record as returned by the Change Management API.
+// Common fields are typed; every returned field is also available in Fields.
+type Change struct {
+	SysID                    table.FieldValue `json:"sys_id"`
+	Number                   table.FieldValue `json:"number"`
+	Type                     table.FieldValue `json:"type"`
+	State                    table.FieldValue `json:"state"`
+	Phase                    table.FieldValue `json:"phase"`
+	ShortDescription         table.FieldValue `json:"short_description"`
+	Description              table.FieldValue `json:"description"`
+	Approval                 table.FieldValue `json:"approval"`
+	Risk                     table.FieldValue `json:"risk"`
+	Impact                   table.FieldValue `json:"impact"`
+	Priority                 table.FieldValue `json:"priority"`
+	AssignmentGroup          table.FieldValue `json:"assignment_group"`
+	AssignedTo               table.FieldValue `json:"assigned_to"`
+	CmdbCI                   table.FieldValue `json:"cmdb_ci"`
+	StartDate                table.FieldValue `json:"start_date"`
+	EndDate                  table.FieldValue `json:"end_date"`
+	ConflictStatus           table.FieldValue `json:"conflict_status"`
+	StdChangeProducerVersion table.FieldValue `json:"std_change_producer_version"`
+
+	Fields map[string]table.FieldValue `json:"-"`
+}
+
+func (c *Change) UnmarshalJSON(data []byte) error {
+	type plain Change
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &p.Fields); err != nil {
+		return err
+	}
+	*c = Change(p)
+	return nil
+}
+
+// Task is a change_task record.
+type Task struct {
+	SysID            table.FieldValue `json:"sys_id"`
+	Number           table.FieldValue `json:"number"`
+	State            table.FieldValue `json:"state"`
+	ChangeTaskType   table.FieldValue `json:"change_task_type"`
+	ShortDescription table.FieldValue `json:"short_description"`
+	Description      table.FieldValue `json:"description"`
+	AssignmentGroup  table.FieldValue `json:"assignment_group"`
+	AssignedTo       table.FieldValue `json:"assigned_to"`
+	PlannedStartDate table.FieldValue `json:"planned_start_date"`
+	PlannedEndDate   table.FieldValue `json:"planned_end_date"`
+
+	Fields map[string]table.FieldValue `json:"-"`
+}
+
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type plain Task
+	var p plain
+	if err := json.Unmarshal(data, &p); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, &p.Fields); err != nil {
+		return err
+	}
+	*t = Task(p)
+	return nil
+}
+
+// StandardTemplate is a standard change template (std_change_record_producer).
+type StandardTemplate struct {
+	SysID            table.FieldValue `json:"sys_id"`
+	Name             table.FieldValue `json:"name"`
+	ShortDescription table.FieldValue `json:"short_description"`
+	Category         table.FieldValue `json:"category"`
+	Active           table.FieldValue `json:"active"`
+}
+
+// NextStates lists the states a change can move to from its current state.
+type NextStates struct {
+	AvailableStates  []string          `json:"available_states"`
+	StateTransitions []StateTransition `json:"state_transitions"`
+	StateLabels      map[string]string `json:"state_label"`
+}
+
+// Allows reports whether state is one of the available next states.
+func (n *NextStates) Allows(state string) bool {
+	if n == nil {
+		return false
+	}
+	for _, s := range n.AvailableStates {
+		if s == state {
+			return true
+		}
+	}
+	return false
+}
+
+// StateTransition is a state model transition with its conditions.
+type StateTransition struct {
+	SysID               string                `json:"sys_id"`
+	DisplayValue        string                `json:"display_value"`
+	FromState           string                `json:"from_state"`
+	ToState             string                `json:"to_state"`
+	TransitionAvailable bool                  `json:"transition_available"`
+	AutomaticTransition bool                  `json:"automatic_transition"`
+	Conditions          []TransitionCondition `json:"conditions"`
+}
+
+// TransitionCondition is a condition of a state transition.
+type TransitionCondition struct {
+	Passed    bool `json:"passed"`
+	Condition struct {
+		SysID        string `json:"sys_id"`
+		DisplayValue string `json:"display_value"`
+		Description  string `json:"description"`
+	} `json:"condition"`
+}
+
+// AssociationType is how a CI is associated with a change.
+type AssociationType string
+
+const (
+	AssociationAffected AssociationType = "affected"
+	AssociationImpacted AssociationType = "impacted"
+	AssociationOffering AssociationType = "offering"
+)
+
+// CIAssociation is a CI associated with a change (task_ci, task_cmdb_ci_service
+// or task_service_offering record).
+type CIAssociation struct {
+	SysID  table.FieldValue `json:"sys_id"`
+	Task   table.FieldValue `json:"task"`
+	CIItem table.FieldValue `json:"ci_item"`
+}
+
+// ConflictState is the state of a conflict detection run.
+type ConflictState string
+
+const (
+	ConflictStateRequested ConflictState = "Requested"
+	ConflictStateExecuting ConflictState = "Executing"
+	ConflictStateCompleted ConflictState = "Completed"
+	ConflictStateFailed    ConflictState = "Failed"
+	ConflictStateCanceled  ConflictState = "Canceled"
+)
+
+// Done reports whether the conflict detection run has finished.
+func (s ConflictState) Done() bool {
+	switch s {
+	case ConflictStateCompleted, ConflictStateFailed, ConflictStateCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ConflictStatus is the status of a conflict detection run.
+type ConflictStatus struct {
+	State   ConflictState `json:"state"`
+	Message string        `json:"message,omitempty"`
+}
+
+// ConflictResult is the outcome of a conflict detection run.
+type ConflictResult struct {
+	Status    ConflictStatus `json:"status"`
+	Conflicts []Conflict     `json:"conflicts"`
+}
+
+// Conflict is a change_conflict record.
+type Conflict struct {
+	SysID             table.FieldValue `json:"sys_id"`
+	Change            table.FieldValue `json:"change"`
+	Type              table.FieldValue `json:"type"`
+	ConfigurationItem table.FieldValue `json:"configuration_item"`
+	ConflictingChange table.FieldValue `json:"conflicting_change"`
+	Schedule          table.FieldValue `json:"schedule"`
+}
+
+// ApprovalState is the decision on a change approval.
+type ApprovalState string
+
+const (
+	ApprovalApproved ApprovalState = "approved"
+	ApprovalRejected ApprovalState = "rejected"
+)
+
+// ListResponse is a page of records.
+type ListResponse[T any] struct {
+	Result []T `json:"result"`
+}
+
+// Internal envelope types matching the ServiceNow result wrapper.
+type resultOne[T any] struct {
+	Result T `json:"result"`
+}
@@ -0,0 +1,159 @@
+package change
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+var (
+	ErrInvalidAssociationType = errors.New("association type must be 'affected', 'impacted' or 'offering'")
+	ErrEmptyCIList            = errors.New("at least one CI sys_id is required")
+)
+
+// ListTasks returns the change tasks of a change.
+func (c *Client) ListTasks(ctx context.Context, changeSysID string, opts *ListOptions) (*ListResponse[Task], error) {
+	p, err := changePath(changeSysID)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out ListResponse[Task]
+	if err := c.do(ctx, http.MethodGet, path.Join(p, "task"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (c *Client) GetTask(ctx context.Context, changeSysID, taskSysID string) (*Task, error) {
+	p, err := taskPath(changeSysID, taskSysID)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[Task]
+	if err := c.do(ctx, http.MethodGet, p, nil, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) CreateTask(ctx context.Context, changeSysID string, fields map[string]any) (*Task, error) {
+	p, err := changePath(changeSysID)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, table.ErrNilInput
+	}
+
+	var out resultOne[Task]
+	if err := c.do(ctx, http.MethodPost, path.Join(p, "task"), nil, fields, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) UpdateTask(ctx context.Context, changeSysID, taskSysID string, fields map[string]any) (*Task, error) {
+	p, err := taskPath(changeSysID, taskSysID)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, table.ErrNilInput
+	}
+
+	var out resultOne[Task]
+	if err := c.do(ctx, http.MethodPatch, p, nil, fields, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) DeleteTask(ctx context.Context, changeSysID, taskSysID string) error {
+	p, err := taskPath(changeSysID, taskSysID)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, p, nil, nil, nil)
+}
+
+// ListCIs returns the CIs associated with a change.
+func (c *Client) ListCIs(ctx context.Context, changeSysID string, assoc AssociationType) (*ListResponse[CIAssociation], error) {
+	p, err := changePath(changeSysID)
+	if err != nil {
+		return nil, err
+	}
+	if err := assoc.Validate(); err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	q.Set("association_type", string(assoc))
+
+	var out ListResponse[CIAssociation]
+	if err := c.do(ctx, http.MethodGet, path.Join(p, "ci"), q, nil, &out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+// AssociateCIs associates CIs with a change. ServiceNow processes the
+// association asynchronously; use ListCIs to see the result.
+func (c *Client) AssociateCIs(ctx context.Context, changeSysID string, assoc AssociationType, ciSysIDs ...string) error {
+	p, err := changePath(changeSysID)
+	if err != nil {
+		return err
+	}
+	if err := assoc.Validate(); err != nil {
+		return err
+	}
+
+	ids := make([]string, 0, len(ciSysIDs))
+	for _, id := range ciSysIDs {
+		if id = strings.TrimSpace(id); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return ErrEmptyCIList
+	}
+
+	body := map[string]string{
+		"cmdb_ci_sys_ids":  strings.Join(ids, ","),
+		"association_type": string(assoc),
+	}
+	return c.do(ctx, http.MethodPost, path.Join(p, "ci"), nil, body, nil)
+}
+
+func (a AssociationType) Validate() error {
+	switch a {
+	case AssociationAffected, AssociationImpacted, AssociationOffering:
+		return nil
+	default:
+		return ErrInvalidAssociationType
+	}
+}
+
+func taskPath(changeSysID, taskSysID string) (string, error) {
+	p, err := changePath(changeSysID)
+	if err != nil {
+		return "", err
+	}
+
+	taskSysID = strings.TrimSpace(taskSysID)
+	if taskSysID == "" || strings.ContainsAny(taskSysID, `/\\`) {
+		return "", table.ErrInvalidSysID
+	}
+	return path.Join(p, "task", taskSysID), nil
+}
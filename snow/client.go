@@ -14,6 +14,13 @@ type Client struct {
 	userAgent string
 
 	defaultHeaders http.Header
+
+	readOnly      bool
+	protected     map[string]bool
+	overrideToken string
+
+	dryRun  *DryRunRecorder
+	limiter *rateLimiter
 }
 
 func NewClient(opts ...Option) (*Client, error) {
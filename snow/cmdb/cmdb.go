@@ -0,0 +1,250 @@
+package cmdb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const instanceAPIPath = "/api/now/cmdb/instance"
+
+var (
+	ErrInvalidClassName = errors.New("invalid CI class name")
+	ErrMissingSource    = errors.New("CMDB writes require a discovery source")
+)
+
+// Client is a CMDB Instance API client (/api/now/cmdb/instance).
+type Client struct {
+	r snow.Requester
+}
+
+func New(r snow.Requester) (*Client, error) {
+	if r == nil {
+		return nil, table.ErrNilRequester
+	}
+	return &Client{r: r}, nil
+}
+
+func (c *Client) classPath(class string) (string, error) {
+	if c == nil || c.r == nil {
+		return "", table.ErrNilRequester
+	}
+
+	class = strings.TrimSpace(class)
+	if class == "" || strings.ContainsAny(class, `/\\`) {
+		return "", ErrInvalidClassName
+	}
+	return path.Join(instanceAPIPath, class), nil
+}
+
+func (c *Client) ciPath(class, sysID string) (string, error) {
+	base, err := c.classPath(class)
+	if err != nil {
+		return "", err
+	}
+
+	sysID = strings.TrimSpace(sysID)
+	if sysID == "" || strings.ContainsAny(sysID, `/\\`) {
+		return "", table.ErrInvalidSysID
+	}
+	return path.Join(base, sysID), nil
+}
+
+// List returns the CIs of class matching opts.
+func (c *Client) List(ctx context.Context, class string, opts *ListOptions) (*ListResponse, error) {
+	base, err := c.classPath(class)
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodGet, base, q, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out ListResponse
+	if err := c.r.Do(req, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// ListWithRelations is like List, but fetches every matching CI with its
+// attributes and relations. It makes one extra request per CI.
+func (c *Client) ListWithRelations(ctx context.Context, class string, opts *ListOptions) ([]*CI, error) {
+	list, err := c.List(ctx, class, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*CI, 0, len(list.Result))
+	for _, summary := range list.Result {
+		ci, err := c.Get(ctx, class, summary.SysID)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ci)
+	}
+
+	return out, nil
+}
+
+// Get returns a CI with its attributes and inbound and outbound relations.
+func (c *Client) Get(ctx context.Context, class, sysID string) (*CI, error) {
+	ciPath, err := c.ciPath(class, sysID)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodGet, ciPath, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[CI]
+	if err := c.r.Do(req, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Result, nil
+}
+
+// Create creates a CI of class together with its relations.
+func (c *Client) Create(ctx context.Context, class string, in *CIInput) (*CI, error) {
+	base, err := c.classPath(class)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.write(ctx, http.MethodPost, base, in)
+}
+
+// Update patches the attributes of a CI. Relations in the input are added.
+func (c *Client) Update(ctx context.Context, class, sysID string, in *CIInput) (*CI, error) {
+	ciPath, err := c.ciPath(class, sysID)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.write(ctx, http.MethodPatch, ciPath, in)
+}
+
+// Replace overwrites the attributes of a CI.
+func (c *Client) Replace(ctx context.Context, class, sysID string, in *CIInput) (*CI, error) {
+	ciPath, err := c.ciPath(class, sysID)
+	if err != nil {
+		return nil, err
+	}
+	if err := in.validate(); err != nil {
+		return nil, err
+	}
+
+	return c.write(ctx, http.MethodPut, ciPath, in)
+}
+
+// AddRelations adds inbound and outbound relations to an existing CI.
+func (c *Client) AddRelations(ctx context.Context, class, sysID string, in *RelationsInput) (*CI, error) {
+	ciPath, err := c.ciPath(class, sysID)
+	if err != nil {
+		return nil, err
+	}
+	if in == nil {
+		return nil, table.ErrNilInput
+	}
+	if strings.TrimSpace(in.Source) == "" {
+		return nil, ErrMissingSource
+	}
+
+	return c.write(ctx, http.MethodPost, path.Join(ciPath, "relation"), in)
+}
+
+// DeleteRelation deletes the relation relSysID of a CI.
+func (c *Client) DeleteRelation(ctx context.Context, class, sysID, relSysID string) error {
+	ciPath, err := c.ciPath(class, sysID)
+	if err != nil {
+		return err
+	}
+
+	relSysID = strings.TrimSpace(relSysID)
+	if relSysID == "" || strings.ContainsAny(relSysID, `/\\`) {
+		return table.ErrInvalidSysID
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodDelete, path.Join(ciPath, "relation", relSysID), nil, nil)
+	if err != nil {
+		return err
+	}
+
+	return c.r.Do(req, nil)
+}
+
+// SourceInfo lists the sys_object_source_info records of a CI.
+func (c *Client) SourceInfo(ctx context.Context, sysID string) ([]SourceInfo, error) {
+	if c == nil || c.r == nil {
+		return nil, table.ErrNilRequester
+	}
+
+	sysID = strings.TrimSpace(sysID)
+	if sysID == "" {
+		return nil, table.ErrInvalidSysID
+	}
+
+	query, err := table.NewQueryBuilder().Eq("target_sys_id", sysID).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := table.New[SourceInfo](c.r, "sys_object_source_info")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := records.List(ctx, &table.ListOptions{Query: query})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Result, nil
+}
+
+func (c *Client) write(ctx context.Context, method, p string, in any) (*CI, error) {
+	req, err := c.r.NewRequest(ctx, method, p, nil, in)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[CI]
+	if err := c.r.Do(req, &out); err != nil {
+		return nil, err
+	}
+
+	return &out.Result, nil
+}
+
+func (in *CIInput) validate() error {
+	if in == nil {
+		return table.ErrNilInput
+	}
+	if strings.TrimSpace(in.Source) == "" {
+		return ErrMissingSource
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package cmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/internal/snowtest"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc, opts ...snow.Option) *Client {
+	t.Helper()
+
+	c, err := New(snowtest.NewClient(t, h, opts...))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestCreate(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/now/cmdb/instance/cmdb_ci_linux_server" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+
+		var in CIInput
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		if in.Source != "ServiceNow" || len(in.OutboundRelations) != 1 {
+			t.Errorf("body = %+v", in)
+		}
+
+		_, _ = w.Write([]byte(`{"result":{"attributes":{"sys_id":"abc","name":"web01"},"outbound_relations":[{"sys_id":"rel1","type":{"value":"t1","display_value":"Runs on::Runs"},"target":{"value":"def","display_value":"host01"}}]}}`))
+	})
+
+	ci, err := c.Create(context.Background(), "cmdb_ci_linux_server", &CIInput{
+		Attributes:        map[string]any{"name": "web01"},
+		OutboundRelations: []RelationInput{{Type: "t1", Target: "def"}},
+		Source:            "ServiceNow",
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if ci.SysID() != "abc" || len(ci.OutboundRelations) != 1 || ci.OutboundRelations[0].Target.Value != "def" {
+		t.Fatalf("Create() = %+v", ci)
+	}
+}
+
+func TestCreateRequiresSource(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := c.Create(context.Background(), "cmdb_ci_linux_server", &CIInput{})
+	if !errors.Is(err, ErrMissingSource) {
+		t.Fatalf("Create() error = %v, want %v", err, ErrMissingSource)
+	}
+}
+
+func TestIdentify(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/now/identifyreconcile/query" || r.URL.Query().Get("sysparm_data_source") != "ServiceNow" {
+			t.Errorf("request = %s %s", r.Method, r.URL)
+		}
+		_, _ = w.Write([]byte(`{"result":"{\"items\":[{\"className\":\"cmdb_ci_linux_server\",\"operation\":\"NO_CHANGE\",\"sysId\":\"abc\",\"identifierEntrySysId\":\"ie1\",\"errors\":[{\"error\":\"MISSING_MATCHING_ATTRIBUTES\",\"message\":\"no serial\"}]}]}"}`))
+	})
+
+	res, err := c.Identify(context.Background(), &IREPayload{
+		Items: []IREItem{{
+			ClassName:  "cmdb_ci_linux_server",
+			Values:     map[string]any{"name": "web01"},
+			SourceInfo: &SourceInfo{SourceName: "ServiceNow", SourceNativeKey: "web01"},
+		}},
+	}, &IREOptions{DataSource: "ServiceNow"})
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+
+	if len(res.Items) != 1 || res.Items[0].Operation != OperationNoChange || res.Items[0].SysID != "abc" || res.Items[0].IdentifierEntrySysID != "ie1" {
+		t.Fatalf("Identify() = %+v", res)
+	}
+	if errs := res.Errors(); len(errs) != 1 || errs[0].Code != "MISSING_MATCHING_ATTRIBUTES" {
+		t.Fatalf("Errors() = %+v", errs)
+	}
+}
+
+func TestIdentifyDryRun(t *testing.T) {
+	rec := snow.NewDryRunRecorder()
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/now/identifyreconcile/query" {
+			t.Errorf("%s %s sent in dry-run mode", r.Method, r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"result":{"items":[{"className":"cmdb_ci_linux_server","operation":"UPDATE","sysId":"abc"}]}}`))
+	}, snow.WithDryRun(rec))
+
+	payload := &IREPayload{Items: []IREItem{{ClassName: "cmdb_ci_linux_server", Values: map[string]any{"name": "web01"}}}}
+	res, err := c.Identify(context.Background(), payload, &IREOptions{DataSource: "ServiceNow"})
+	if err != nil {
+		t.Fatalf("Identify() error = %v", err)
+	}
+	if len(res.Items) != 1 || res.Items[0].Operation != OperationUpdate || res.Items[0].SysID != "abc" {
+		t.Fatalf("Identify() = %+v", res)
+	}
+	if writes := rec.Writes(); len(writes) != 0 {
+		t.Fatalf("Writes() = %+v, want none", writes)
+	}
+}
+
+func TestIdentifyRequiresDataSource(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	})
+
+	_, err := c.IdentifyReconcile(context.Background(), &IREPayload{
+		Items: []IREItem{{ClassName: "cmdb_ci_linux_server"}},
+	}, nil)
+	if !errors.Is(err, ErrMissingDataSource) {
+		t.Fatalf("IdentifyReconcile() error = %v, want %v", err, ErrMissingDataSource)
+	}
+}
+
+func TestWalk(t *testing.T) {
+	// svc -> app -> db -> svc (cycle)
+	rels := map[string]string{
+		"svc": `{"sys_id":"r1","parent":"svc","parent.name":"Service","parent.sys_class_name":"cmdb_ci_service","child":"app","child.name":"App","child.sys_class_name":"cmdb_ci_appl","type.name":"Depends on::Used by"}`,
+		"app": `{"sys_id":"r2","parent":"app","child":"db","child.name":"DB\\","child.sys_class_name":"cmdb_ci_db_instance","type.name":"Depends on::Used by"}`,
+		"db":  `{"sys_id":"r3","parent":"db","child":"svc","type.name":"Depends on::Used by"}`,
+	}
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/now/table/cmdb_rel_ci" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		query := r.URL.Query().Get("sysparm_query")
+		var result []string
+		for parent, rel := range rels {
+			if strings.HasPrefix(query, "parentIN"+parent+"^") {
+				result = append(result, rel)
+			}
+		}
+		_, _ = w.Write([]byte(`{"result":[` + strings.Join(result, ",") + `]}`))
+	})
+
+	g, err := c.Walk(context.Background(), "svc", &GraphOptions{MaxDepth: 5})
+	if err != nil {
+		t.Fatalf("Walk() error = %v", err)
+	}
+
+	if len(g.Nodes) != 3 || len(g.Edges) != 3 {
+		t.Fatalf("Walk() nodes = %d, edges = %d, want 3 and 3", len(g.Nodes), len(g.Edges))
+	}
+	if n := g.Nodes["db"]; n == nil || n.Depth != 2 || n.Class != "cmdb_ci_db_instance" {
+		t.Fatalf("Walk() db node = %+v", n)
+	}
+	if g.Nodes["svc"].Name != "Service" {
+		t.Fatalf("Walk() root node = %+v", g.Nodes["svc"])
+	}
+	if dot := g.DOT(); !strings.Contains(dot, `"app" -> "db" [label="Depends on::Used by"]`) ||
+		!strings.Contains(dot, `"db" [label="DB\\\ncmdb_ci_db_instance"]`) {
+		t.Fatalf("DOT() = %s", dot)
+	}
+}
@@ -0,0 +1,349 @@
+package cmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const (
+	defaultGraphBatchSize   = 100
+	defaultGraphConcurrency = 4
+	graphPageSize           = 1000
+)
+
+var ErrInvalidMaxDepth = errors.New("MaxDepth must be > 0")
+
+// Direction selects which relations a graph walk follows.
+type Direction string
+
+const (
+	// Downstream follows relations from parent to child, e.g. from an
+	// application service to the CIs it depends on.
+	Downstream Direction = "downstream"
+	// Upstream follows relations from child to parent.
+	Upstream Direction = "upstream"
+	// Both follows relations in both directions.
+	Both Direction = "both"
+)
+
+// GraphOptions configures Walk.
+type GraphOptions struct {
+	MaxDepth  int       // Number of hops from the root; must be > 0
+	Direction Direction // Defaults to Downstream
+
+	// RelationTypes limits the walk to relation types by name, e.g. "Depends on::Used by".
+	RelationTypes []string
+	// Classes limits the walk to CIs of these classes. The root is always included.
+	Classes []string
+
+	BatchSize   int // sys_ids per IN query; defaults to 100
+	Concurrency int // concurrent queries per hop; defaults to 4
+}
+
+// Graph is an in-memory CI relationship graph.
+type Graph struct {
+	Root  string
+	Nodes map[string]*Node
+	Edges []Edge
+}
+
+// Node is a CI in a Graph.
+type Node struct {
+	SysID string `json:"sys_id"`
+	Name  string `json:"name,omitempty"`
+	Class string `json:"sys_class_name,omitempty"`
+	Depth int    `json:"depth"`
+}
+
+// Edge is a cmdb_rel_ci relation in a Graph.
+type Edge struct {
+	SysID  string `json:"sys_id"`
+	Parent string `json:"parent"`
+	Child  string `json:"child"`
+	Type   string `json:"type"`
+}
+
+type relEnd struct {
+	Value string `json:"value"`
+	Name  string `json:"name"`
+	Class string `json:"sys_class_name"`
+}
+
+type relRecord struct {
+	SysID  string `json:"sys_id"`
+	Parent relEnd `json:"parent"`
+	Child  relEnd `json:"child"`
+	Type   struct {
+		Value string `json:"value"`
+		Name  string `json:"name"`
+	} `json:"type"`
+}
+
+// Walk traverses cmdb_rel_ci breadth-first from root up to opts.MaxDepth hops.
+// Each hop queries the relations of the whole frontier in batches of IN
+// queries, running them concurrently. CIs already visited are not expanded
+// again, so cycles terminate.
+func (c *Client) Walk(ctx context.Context, root string, opts *GraphOptions) (*Graph, error) {
+	if c == nil || c.r == nil {
+		return nil, table.ErrNilRequester
+	}
+
+	root = strings.TrimSpace(root)
+	if root == "" {
+		return nil, table.ErrInvalidSysID
+	}
+
+	o := GraphOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.MaxDepth <= 0 {
+		return nil, ErrInvalidMaxDepth
+	}
+	if o.Direction == "" {
+		o.Direction = Downstream
+	}
+	switch o.Direction {
+	case Downstream, Upstream, Both:
+	default:
+		return nil, fmt.Errorf("invalid direction %q", o.Direction)
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultGraphBatchSize
+	}
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultGraphConcurrency
+	}
+
+	rels, err := table.New[relRecord](c.r, "cmdb_rel_ci", table.WithStructFields(), table.WithDotWalking())
+	if err != nil {
+		return nil, err
+	}
+
+	g := &Graph{
+		Root:  root,
+		Nodes: map[string]*Node{root: {SysID: root}},
+	}
+	seenEdges := map[string]bool{}
+	frontier := []string{root}
+
+	for depth := 1; depth <= o.MaxDepth && len(frontier) > 0; depth++ {
+		records, err := fetchHop(ctx, rels, frontier, &o)
+		if err != nil {
+			return nil, err
+		}
+
+		var next []string
+		for _, rec := range records {
+			if rec.SysID == "" || seenEdges[rec.SysID] {
+				continue
+			}
+			seenEdges[rec.SysID] = true
+
+			g.Edges = append(g.Edges, Edge{
+				SysID:  rec.SysID,
+				Parent: rec.Parent.Value,
+				Child:  rec.Child.Value,
+				Type:   rec.Type.Name,
+			})
+
+			for _, end := range []relEnd{rec.Parent, rec.Child} {
+				if end.Value == "" {
+					continue
+				}
+				if n, ok := g.Nodes[end.Value]; ok {
+					if n.Name == "" {
+						n.Name, n.Class = end.Name, end.Class
+					}
+					continue
+				}
+				g.Nodes[end.Value] = &Node{SysID: end.Value, Name: end.Name, Class: end.Class, Depth: depth}
+				next = append(next, end.Value)
+			}
+		}
+
+		sort.Strings(next)
+		frontier = next
+	}
+
+	return g, nil
+}
+
+// fetchHop returns the relations of all CIs in frontier.
+func fetchHop(ctx context.Context, rels *table.Client[relRecord], frontier []string, o *GraphOptions) ([]relRecord, error) {
+	type job struct {
+		from  string // field matched against the frontier
+		to    string // field of the CI on the other end
+		batch []string
+	}
+
+	var jobs []job
+	for start := 0; start < len(frontier); start += o.BatchSize {
+		end := min(start+o.BatchSize, len(frontier))
+		batch := frontier[start:end]
+		if o.Direction == Downstream || o.Direction == Both {
+			jobs = append(jobs, job{from: "parent", to: "child", batch: batch})
+		}
+		if o.Direction == Upstream || o.Direction == Both {
+			jobs = append(jobs, job{from: "child", to: "parent", batch: batch})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		out      []relRecord
+		sem      = make(chan struct{}, o.Concurrency)
+	)
+
+	for _, j := range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			records, err := listRelations(ctx, rels, j.from, j.to, j.batch, o)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			out = append(out, records...)
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// Keep the graph deterministic regardless of completion order.
+	sort.Slice(out, func(i, k int) bool { return out[i].SysID < out[k].SysID })
+	return out, nil
+}
+
+func listRelations(ctx context.Context, rels *table.Client[relRecord], from, to string, ids []string, o *GraphOptions) ([]relRecord, error) {
+	qb := table.NewQueryBuilder().In(from, toAny(ids)...)
+	if len(o.RelationTypes) > 0 {
+		qb.In("type.name", toAny(o.RelationTypes)...)
+	}
+	if len(o.Classes) > 0 {
+		qb.In(to+".sys_class_name", toAny(o.Classes)...)
+	}
+	query, err := qb.Build()
+	if err != nil {
+		return nil, err
+	}
+	query += "^ORDERBYsys_id"
+
+	var out []relRecord
+	for offset := 0; ; offset += graphPageSize {
+		resp, err := rels.List(ctx, &table.ListOptions{
+			Query:                    query,
+			Limit:                    table.Int(graphPageSize),
+			Offset:                   table.Int(offset),
+			ExcludeReferenceLink:     table.Bool(true),
+			SuppressPaginationHeader: table.Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, resp.Result...)
+		if len(resp.Result) < graphPageSize {
+			return out, nil
+		}
+	}
+}
+
+// SortedNodes returns the nodes ordered by depth, then sys_id.
+func (g *Graph) SortedNodes() []*Node {
+	nodes := make([]*Node, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		nodes = append(nodes, n)
+	}
+	sort.Slice(nodes, func(i, k int) bool {
+		if nodes[i].Depth != nodes[k].Depth {
+			return nodes[i].Depth < nodes[k].Depth
+		}
+		return nodes[i].SysID < nodes[k].SysID
+	})
+	return nodes
+}
+
+// MarshalJSON encodes the graph as {"root", "nodes", "edges"} with nodes
+// in SortedNodes order.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Root  string  `json:"root"`
+		Nodes []*Node `json:"nodes"`
+		Edges []Edge  `json:"edges"`
+	}{
+		Root:  g.Root,
+		Nodes: g.SortedNodes(),
+		Edges: g.Edges,
+	})
+}
+
+// DOT renders the graph in Graphviz DOT format.
+func (g *Graph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph cmdb {\n")
+	for _, n := range g.SortedNodes() {
+		lines := []string{n.Name}
+		if n.Name == "" {
+			lines[0] = n.SysID
+		}
+		if n.Class != "" {
+			lines = append(lines, n.Class)
+		}
+		fmt.Fprintf(&sb, "  %q [label=%s];\n", n.SysID, dotQuote(lines...))
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&sb, "  %q -> %q [label=%s];\n", e.Parent, e.Child, dotQuote(e.Type))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// dotQuote quotes lines as one DOT string, joined by \n line breaks.
+func dotQuote(lines ...string) string {
+	escaped := make([]string, len(lines))
+	for i, s := range lines {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+		escaped[i] = strings.ReplaceAll(s, `"`, `\"`)
+	}
+	return `"` + strings.Join(escaped, `\n`) + `"`
+}
+
+func toAny(values []string) []any {
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
+	}
+	return out
+}
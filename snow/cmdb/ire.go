@@ -0,0 +1,122 @@
+package cmdb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const ireAPIPath = "/api/now/identifyreconcile"
+
+var (
+	ErrMissingDataSource = errors.New("identify and reconcile requires a data source")
+	ErrEmptyIREPayload   = errors.New("identify and reconcile payload has no items")
+)
+
+// IREOptions are the query parameters of an IRE call.
+type IREOptions struct {
+	// DataSource is the discovery_source choice the payload comes from. Required.
+	DataSource string
+
+	// Options of the enhanced endpoint; ignored by the others.
+	PartialPayloads     *bool
+	PartialCommits      *bool
+	DeduplicatePayloads *bool
+	GenerateSummary     *bool
+}
+
+func (o *IREOptions) apply(q url.Values, enhanced bool) error {
+	if o == nil || strings.TrimSpace(o.DataSource) == "" {
+		return ErrMissingDataSource
+	}
+	q.Set("sysparm_data_source", strings.TrimSpace(o.DataSource))
+
+	if !enhanced {
+		return nil
+	}
+
+	var opts []string
+	for _, opt := range []struct {
+		name  string
+		value *bool
+	}{
+		{"partial_payloads", o.PartialPayloads},
+		{"partial_commits", o.PartialCommits},
+		{"deduplicate_payloads", o.DeduplicatePayloads},
+		{"generate_summary", o.GenerateSummary},
+	} {
+		if opt.value != nil {
+			opts = append(opts, opt.name+":"+strconv.FormatBool(*opt.value))
+		}
+	}
+	if len(opts) > 0 {
+		q.Set("options", strings.Join(opts, ","))
+	}
+
+	return nil
+}
+
+// IdentifyReconcile identifies the payload items against the CMDB and
+// inserts or updates them.
+func (c *Client) IdentifyReconcile(ctx context.Context, payload *IREPayload, opts *IREOptions) (*IREResult, error) {
+	return c.ire(ctx, ireAPIPath, payload, opts, false)
+}
+
+// Identify runs identification only: nothing is written, and the result
+// reports the operation the IRE would perform for each item.
+func (c *Client) Identify(ctx context.Context, payload *IREPayload, opts *IREOptions) (*IREResult, error) {
+	return c.ire(ctx, ireAPIPath+"/query", payload, opts, false)
+}
+
+// IdentifyReconcileEnhanced uses the enhanced endpoint, which supports
+// partial payloads, partial commits and payload deduplication.
+func (c *Client) IdentifyReconcileEnhanced(ctx context.Context, payload *IREPayload, opts *IREOptions) (*IREResult, error) {
+	return c.ire(ctx, ireAPIPath+"/enhanced", payload, opts, true)
+}
+
+func (c *Client) ire(ctx context.Context, p string, payload *IREPayload, opts *IREOptions, enhanced bool) (*IREResult, error) {
+	if c == nil || c.r == nil {
+		return nil, table.ErrNilRequester
+	}
+	if payload == nil {
+		return nil, table.ErrNilInput
+	}
+	if len(payload.Items) == 0 {
+		return nil, ErrEmptyIREPayload
+	}
+
+	q := url.Values{}
+	if err := opts.apply(q, enhanced); err != nil {
+		return nil, err
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodPost, p, q, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var out resultOne[json.RawMessage]
+	if err := c.r.Do(req, &out); err != nil {
+		return nil, err
+	}
+
+	// Some releases return the result as a JSON-encoded string.
+	raw := out.Result
+	var encoded string
+	if err := json.Unmarshal(raw, &encoded); err == nil {
+		raw = json.RawMessage(encoded)
+	}
+
+	var result IREResult
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,119 @@
+package cmdb
+
+import "fmt"
+
+// IREPayload is the input of the Identification and Reconciliation API.
+type IREPayload struct {
+	Items          []IREItem      `json:"items"`
+	Relations      []IRERelation  `json:"relations,omitempty"`
+	ReferenceItems []IREReference `json:"referenceItems,omitempty"`
+}
+
+// IREItem is a CI to identify and reconcile.
+type IREItem struct {
+	ClassName  string         `json:"className"`
+	Values     map[string]any `json:"values"`
+	Lookup     []IRELookup    `json:"lookup,omitempty"`
+	Related    []IRELookup    `json:"related,omitempty"`
+	InternalID string         `json:"internal_id,omitempty"`
+	Settings   *IRESettings   `json:"settings,omitempty"`
+	SourceInfo *SourceInfo    `json:"sys_object_source_info,omitempty"`
+}
+
+// IRELookup is a lookup or related record used to identify an item.
+type IRELookup struct {
+	ClassName string         `json:"className"`
+	Values    map[string]any `json:"values"`
+}
+
+// IRERelation relates two items of the payload by their index in Items.
+type IRERelation struct {
+	Parent int    `json:"parent"`
+	Child  int    `json:"child"`
+	Type   string `json:"type"` // e.g. "Runs on::Runs"
+}
+
+// IREReference resolves a reference field of an item to another item.
+type IREReference struct {
+	ReferencedBy   int    `json:"referencedBy"`
+	Referenced     int    `json:"referenced"`
+	ReferenceField string `json:"referenceField"`
+}
+
+// IRESettings are per-item reconciliation settings.
+type IRESettings struct {
+	SkipReclassificationRestrictionRules bool `json:"skipReclassificationRestrictionRules,omitempty"`
+	SkipUpdatingLastScanToNow            bool `json:"skipUpdatingLastScanToNow,omitempty"`
+	SkipUpdatingLastDiscoveredToNow      bool `json:"skipUpdatingLastDiscoveredToNow,omitempty"`
+	UpdateWithoutDowngrade               bool `json:"updateWithoutDowngrade,omitempty"`
+	UpdateWithoutSwitch                  bool `json:"updateWithoutSwitch,omitempty"`
+	UpdateWithoutUpgrade                 bool `json:"updateWithoutUpgrade,omitempty"`
+	PartialPayloadCache                  bool `json:"partialPayloadCache,omitempty"`
+}
+
+// Operation is what the IRE did (or would do) with an item.
+type Operation string
+
+const (
+	OperationInsert              Operation = "INSERT"
+	OperationUpdate              Operation = "UPDATE"
+	OperationNoChange            Operation = "NO_CHANGE"
+	OperationDelete              Operation = "DELETE"
+	OperationUpdateWithUpgrade   Operation = "UPDATE_WITH_UPGRADE"
+	OperationUpdateWithDowngrade Operation = "UPDATE_WITH_DOWNGRADE"
+	OperationUpdateWithSwitch    Operation = "UPDATE_WITH_SWITCH"
+)
+
+// IREResult is the per-item outcome of an IRE call.
+type IREResult struct {
+	Items                    []IREItemResult `json:"items"`
+	Relations                []IREItemResult `json:"relations"`
+	AdditionalCommittedItems []IREItemResult `json:"additionalCommittedItems,omitempty"`
+}
+
+// Errors returns the errors of all items and relations.
+func (r *IREResult) Errors() []IREError {
+	if r == nil {
+		return nil
+	}
+
+	var out []IREError
+	for _, list := range [][]IREItemResult{r.Items, r.Relations, r.AdditionalCommittedItems} {
+		for _, item := range list {
+			out = append(out, item.Errors...)
+		}
+	}
+	return out
+}
+
+// IREItemResult is the outcome for a single item or relation.
+type IREItemResult struct {
+	ClassName              string                  `json:"className"`
+	Operation              Operation               `json:"operation"`
+	SysID                  string                  `json:"sysId"`
+	IdentifierEntrySysID   string                  `json:"identifierEntrySysId"`
+	IdentificationAttempts []IdentificationAttempt `json:"identificationAttempts,omitempty"`
+	Errors                 []IREError              `json:"errors,omitempty"`
+	Warnings               []IREError              `json:"warnings,omitempty"`
+}
+
+// IdentificationAttempt describes how an identifier entry was tried.
+type IdentificationAttempt struct {
+	IdentifierName string   `json:"identifierName"`
+	Attributes     []string `json:"attributes"`
+	SearchOnTable  string   `json:"searchOnTable"`
+	AttemptResult  string   `json:"attemptResult"` // MATCHED, NO_MATCH, SKIPPED, MULTI_MATCH
+}
+
+// IREError is an error reported for an item, e.g. MISSING_MATCHING_ATTRIBUTES.
+type IREError struct {
+	Code    string `json:"error"`
+	Message string `json:"message"`
+}
+
+func (e IREError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("identify and reconcile error: %s", e.Code)
+	}
+	return fmt.Sprintf("identify and reconcile error: %s: %s", e.Code, e.Message)
+}
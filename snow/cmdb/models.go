@@ -0,0 +1,83 @@
+package cmdb
+
+// CI is a configuration item as returned by the CMDB Instance API.
+type CI struct {
+	Attributes        map[string]any `json:"attributes"`
+	InboundRelations  []Relation     `json:"inbound_relations"`
+	OutboundRelations []Relation     `json:"outbound_relations"`
+}
+
+// SysID returns the sys_id attribute of the CI.
+func (ci *CI) SysID() string {
+	if ci == nil {
+		return ""
+	}
+	id, _ := ci.Attributes["sys_id"].(string)
+	return id
+}
+
+// CISummary is a list entry of the CMDB Instance API.
+type CISummary struct {
+	SysID string `json:"sys_id"`
+	Name  string `json:"name"`
+}
+
+// Relation is a cmdb_rel_ci record seen from one of its CIs.
+type Relation struct {
+	SysID  string    `json:"sys_id"`
+	Type   Reference `json:"type"`
+	Target Reference `json:"target"`
+}
+
+// Reference is a reference value with its display value and API link.
+type Reference struct {
+	DisplayValue string `json:"display_value"`
+	Link         string `json:"link,omitempty"`
+	Value        string `json:"value"`
+}
+
+// RelationInput describes a relation to create.
+type RelationInput struct {
+	Type        string `json:"type"`                     // cmdb_rel_type sys_id
+	Target      string `json:"target"`                   // sys_id of the other CI
+	TargetClass string `json:"sys_class_name,omitempty"` // class of the other CI
+}
+
+// CIInput is the body for creating or updating a CI.
+type CIInput struct {
+	Attributes        map[string]any  `json:"attributes,omitempty"`
+	InboundRelations  []RelationInput `json:"inbound_relations,omitempty"`
+	OutboundRelations []RelationInput `json:"outbound_relations,omitempty"`
+
+	// Source is a discovery_source choice, e.g. "ServiceNow". Required.
+	Source string `json:"source"`
+}
+
+// RelationsInput is the body for adding relations to an existing CI.
+type RelationsInput struct {
+	InboundRelations  []RelationInput `json:"inbound_relations,omitempty"`
+	OutboundRelations []RelationInput `json:"outbound_relations,omitempty"`
+	Source            string          `json:"source"`
+}
+
+// SourceInfo is a sys_object_source_info record, tracking which discovery
+// source reported a CI and under which native key.
+type SourceInfo struct {
+	SysID                  string `json:"sys_id,omitempty"`
+	SourceName             string `json:"source_name"`
+	SourceNativeKey        string `json:"source_native_key"`
+	SourceFeed             string `json:"source_feed,omitempty"`
+	SourceRecencyTimestamp string `json:"source_recency_timestamp,omitempty"`
+	TargetSysID            string `json:"target_sys_id,omitempty"`
+	TargetTable            string `json:"target_table,omitempty"`
+}
+
+// ListResponse is a page of CIs of a class.
+type ListResponse struct {
+	Result []CISummary `json:"result"`
+}
+
+// Internal envelope types matching the ServiceNow result wrapper.
+type resultOne[T any] struct {
+	Result T `json:"result"`
+}
@@ -0,0 +1,41 @@
+package cmdb
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// ListOptions filters and pages a CI list.
+type ListOptions struct {
+	Query  string // Encoded query string (build with table.QueryBuilder)
+	Limit  *int
+	Offset *int
+}
+
+func (o *ListOptions) apply(q url.Values) error {
+	if o == nil {
+		return nil
+	}
+
+	if o.Limit != nil && *o.Limit < 0 {
+		return table.ErrInvalidLimit
+	}
+	if o.Offset != nil && *o.Offset < 0 {
+		return table.ErrInvalidOffset
+	}
+
+	if query := strings.TrimSpace(o.Query); query != "" {
+		q.Set("sysparm_query", query)
+	}
+	if o.Limit != nil {
+		q.Set("sysparm_limit", strconv.Itoa(*o.Limit))
+	}
+	if o.Offset != nil {
+		q.Set("sysparm_offset", strconv.Itoa(*o.Offset))
+	}
+
+	return nil
+}
@@ -0,0 +1,271 @@
+// Package config loads named instance profiles (dev, test, prod, ...) from a
+// file and the environment and builds snow clients from them.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+const (
+	EnvInstance = "SNOW_INSTANCE"
+	EnvUser     = "SNOW_USER"
+	EnvPassword = "SNOW_PASSWORD"
+	EnvProfile  = "SNOW_PROFILE"
+
+	// DefaultProfile names the profile built from the environment alone.
+	DefaultProfile = "default"
+
+	AuthBasic = "basic"
+)
+
+var (
+	ErrNoProfiles          = errors.New("no profiles configured")
+	ErrUnknownProfile      = errors.New("unknown profile")
+	ErrUnsupportedAuthType = errors.New("unsupported auth type")
+	ErrUnsupportedFormat   = errors.New("unsupported config file format")
+)
+
+// Config is a set of named profiles.
+type Config struct {
+	// Default is the profile used when none is named; SNOW_PROFILE overrides
+	// it. With a single profile, that one is the default.
+	Default  string             `json:"default"`
+	Profiles map[string]Profile `json:"profiles"`
+}
+
+// Profile holds the client settings for one instance.
+type Profile struct {
+	Instance  string            `json:"instance"`
+	Auth      Auth              `json:"auth"`
+	Timeout   Duration          `json:"timeout"` // HTTP client timeout; the snow default if zero
+	RateLimit RateLimit         `json:"rate_limit"`
+	Headers   map[string]string `json:"headers"`
+	UserAgent string            `json:"user_agent"`
+}
+
+// Auth selects how a profile authenticates. Only AuthBasic is supported.
+type Auth struct {
+	Type     string `json:"type"` // defaults to AuthBasic
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// RateLimit configures snow.WithRateLimit; zero RequestsPerSecond disables it.
+type RateLimit struct {
+	RequestsPerSecond float64 `json:"requests_per_second"`
+	Burst             int     `json:"burst"` // defaults to 1
+}
+
+// Duration is a time.Duration read from a string such as "30s" or a number
+// of seconds.
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case nil:
+		*d = 0
+	case float64:
+		*d = Duration(v * float64(time.Second))
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = Duration(parsed)
+	default:
+		return fmt.Errorf("invalid duration %s", data)
+	}
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// Load reads the profiles in path and applies the environment overrides (see
+// ApplyEnv). Files ending in .json are JSON; .toml files use a TOML subset
+// (tables, dotted keys, strings, numbers and booleans). An empty path loads
+// the environment alone.
+func Load(path string) (*Config, error) {
+	cfg := &Config{}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".json":
+			cfg, err = ParseJSON(data)
+		case ".toml":
+			cfg, err = ParseTOML(data)
+		default:
+			err = fmt.Errorf("%w: %s", ErrUnsupportedFormat, path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	cfg.ApplyEnv(os.Getenv)
+	if len(cfg.Profiles) == 0 {
+		return nil, ErrNoProfiles
+	}
+	return cfg, nil
+}
+
+// ParseJSON parses a JSON config.
+func ParseJSON(data []byte) (*Config, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	return &cfg, nil
+}
+
+// ParseTOML parses a config in the supported TOML subset.
+func ParseTOML(data []byte) (*Config, error) {
+	doc, err := parseTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return ParseJSON(raw)
+}
+
+// ApplyEnv applies the environment overrides read with getenv: SNOW_PROFILE
+// selects the default profile, and SNOW_INSTANCE, SNOW_USER and
+// SNOW_PASSWORD override the settings of that profile, creating it if
+// needed.
+func (c *Config) ApplyEnv(getenv func(string) string) {
+	if name := strings.TrimSpace(getenv(EnvProfile)); name != "" {
+		c.Default = name
+	}
+
+	instance, user, password := getenv(EnvInstance), getenv(EnvUser), getenv(EnvPassword)
+	if instance == "" && user == "" && password == "" {
+		return
+	}
+
+	name := c.defaultName()
+	if name == "" {
+		name = DefaultProfile
+	}
+	if c.Profiles == nil {
+		c.Profiles = map[string]Profile{}
+	}
+	p := c.Profiles[name]
+	if instance != "" {
+		p.Instance = instance
+	}
+	if user != "" {
+		p.Auth.Username = user
+	}
+	if password != "" {
+		p.Auth.Password = password
+	}
+	c.Profiles[name] = p
+}
+
+// Names returns the profile names, sorted.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile returns the named profile, or the default one if name is empty.
+func (c *Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = c.defaultName()
+	}
+	p, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("%w: %q", ErrUnknownProfile, name)
+	}
+	return p, nil
+}
+
+func (c *Config) defaultName() string {
+	if c.Default != "" {
+		return c.Default
+	}
+	if len(c.Profiles) == 1 {
+		for name := range c.Profiles {
+			return name
+		}
+	}
+	return ""
+}
+
+// Options returns the snow options for the profile.
+func (p Profile) Options() ([]snow.Option, error) {
+	opts := []snow.Option{snow.WithInstanceURL(p.Instance)}
+
+	switch strings.ToLower(p.Auth.Type) {
+	case "", AuthBasic:
+		opts = append(opts, snow.WithBasicAuth(p.Auth.Username, p.Auth.Password))
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAuthType, p.Auth.Type)
+	}
+
+	if p.Timeout > 0 {
+		opts = append(opts, snow.WithHTTPClient(&http.Client{Timeout: time.Duration(p.Timeout)}))
+	}
+	if p.RateLimit.RequestsPerSecond > 0 {
+		opts = append(opts, snow.WithRateLimit(p.RateLimit.RequestsPerSecond, max(p.RateLimit.Burst, 1)))
+	}
+	if p.UserAgent != "" {
+		opts = append(opts, snow.WithUserAgent(p.UserAgent))
+	}
+	keys := make([]string, 0, len(p.Headers))
+	for k := range p.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		opts = append(opts, snow.WithDefaultHeader(k, p.Headers[k]))
+	}
+	return opts, nil
+}
+
+// NewClient builds a client for the named profile (the default one if name
+// is empty). extra options are applied after the profile's.
+func (c *Config) NewClient(name string, extra ...snow.Option) (*snow.Client, error) {
+	if name == "" {
+		name = c.defaultName()
+	}
+	p, err := c.Profile(name)
+	if err != nil {
+		return nil, err
+	}
+	opts, err := p.Options()
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	client, err := snow.NewClient(append(opts, extra...)...)
+	if err != nil {
+		return nil, fmt.Errorf("profile %q: %w", name, err)
+	}
+	return client, nil
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseTOML(t *testing.T) {
+	if _, err := ParseTOML([]byte("[profiles.dev]\nauth = { }\n")); err == nil {
+		t.Fatal("ParseTOML() with inline table error = nil")
+	}
+
+	data := `
+default = "dev"
+
+# Development instance
+[profiles.dev]
+instance = "https://dev12345.service-now.com"
+auth.username = "admin"
+auth.password = "p#ss" # quoted hash is kept
+timeout = "45s"
+
+[profiles.dev.headers]
+"X-Team" = 'platform'
+
+[profiles.prod]
+instance = "https://acme.service-now.com"
+timeout = 10
+
+[profiles.prod.rate_limit]
+requests_per_second = 2.5
+burst = 5
+`
+	cfg, err := ParseTOML([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseTOML() error = %v", err)
+	}
+	dev, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if dev.Instance != "https://dev12345.service-now.com" || dev.Auth.Username != "admin" || dev.Auth.Password != "p#ss" ||
+		time.Duration(dev.Timeout) != 45*time.Second || dev.Headers["X-Team"] != "platform" {
+		t.Errorf("dev = %+v", dev)
+	}
+	prod := cfg.Profiles["prod"]
+	if time.Duration(prod.Timeout) != 10*time.Second || prod.RateLimit.RequestsPerSecond != 2.5 || prod.RateLimit.Burst != 5 {
+		t.Errorf("prod = %+v", prod)
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snow.json")
+	if err := os.WriteFile(path, []byte(`{
+		"default": "dev",
+		"profiles": {
+			"dev":  {"instance": "https://dev.service-now.com", "auth": {"username": "dev-user", "password": "x"}},
+			"prod": {"instance": "https://prod.service-now.com", "auth": {"type": "oauth"}}
+		}
+	}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(EnvProfile, "uat")
+	t.Setenv(EnvInstance, "https://uat.service-now.com")
+	t.Setenv(EnvUser, "ci")
+	t.Setenv(EnvPassword, "secret")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	uat, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("Profile() error = %v", err)
+	}
+	if uat.Instance != "https://uat.service-now.com" || uat.Auth.Username != "ci" || uat.Auth.Password != "secret" {
+		t.Errorf("uat = %+v", uat)
+	}
+	if cfg.Profiles["dev"].Auth.Username != "dev-user" {
+		t.Errorf("dev = %+v", cfg.Profiles["dev"])
+	}
+
+	reg, err := NewRegistry(cfg)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	if _, err := reg.Client("prod"); !errors.Is(err, ErrUnsupportedAuthType) {
+		t.Errorf("Client(prod) error = %v, want ErrUnsupportedAuthType", err)
+	}
+	if _, err := reg.Client("qa"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("Client(qa) error = %v, want ErrUnknownProfile", err)
+	}
+	a, err := reg.Default()
+	if err != nil {
+		t.Fatalf("Default() error = %v", err)
+	}
+	if b, _ := reg.Client("uat"); a != b {
+		t.Error("Client(uat) built a second client")
+	}
+}
+
+func TestLoadEnvOnly(t *testing.T) {
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvInstance, "")
+	t.Setenv(EnvUser, "")
+	t.Setenv(EnvPassword, "")
+	if _, err := Load(""); !errors.Is(err, ErrNoProfiles) {
+		t.Fatalf("Load() error = %v, want ErrNoProfiles", err)
+	}
+
+	t.Setenv(EnvInstance, "https://dev.service-now.com")
+	t.Setenv(EnvUser, "admin")
+	t.Setenv(EnvPassword, "secret")
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if _, ok := cfg.Profiles[DefaultProfile]; !ok || len(cfg.Profiles) != 1 {
+		t.Fatalf("Profiles = %+v", cfg.Profiles)
+	}
+}
+
+func TestProfileClient(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, _, _ := r.BasicAuth(); user != "admin" {
+			t.Errorf("user = %q", user)
+		}
+		if r.Header.Get("X-Team") != "platform" || r.Header.Get("User-Agent") != "sync-job/1.0" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		w.Write([]byte(`{"result":[]}`))
+	}))
+	defer srv.Close()
+
+	cfg := &Config{Profiles: map[string]Profile{"dev": {
+		Instance:  srv.URL,
+		Auth:      Auth{Username: "admin", Password: "secret"},
+		Timeout:   Duration(5 * time.Second),
+		RateLimit: RateLimit{RequestsPerSecond: 100},
+		Headers:   map[string]string{"X-Team": "platform"},
+		UserAgent: "sync-job/1.0",
+	}}}
+	c, err := cfg.NewClient("")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	req, err := c.NewRequest(context.Background(), http.MethodGet, "/api/now/table/incident", nil, nil)
+	if err != nil {
+		t.Fatalf("NewRequest() error = %v", err)
+	}
+	if err := c.Do(req, nil); err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+}
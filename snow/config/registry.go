@@ -0,0 +1,57 @@
+package config
+
+import (
+	"sync"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+// Registry hands out one client per profile, built on first use, so a broken
+// profile does not keep the others from working. It is safe for concurrent
+// use.
+type Registry struct {
+	cfg   *Config
+	extra []snow.Option
+
+	mu      sync.Mutex
+	clients map[string]*snow.Client
+}
+
+// NewRegistry returns a registry for the profiles in cfg. extra options are
+// applied to every client after the profile's own.
+func NewRegistry(cfg *Config, extra ...snow.Option) (*Registry, error) {
+	if cfg == nil || len(cfg.Profiles) == 0 {
+		return nil, ErrNoProfiles
+	}
+	return &Registry{cfg: cfg, extra: extra, clients: map[string]*snow.Client{}}, nil
+}
+
+// Client returns the client of the named profile, or of the default profile
+// if name is empty.
+func (r *Registry) Client(name string) (*snow.Client, error) {
+	if name == "" {
+		name = r.cfg.defaultName()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if c, ok := r.clients[name]; ok {
+		return c, nil
+	}
+	c, err := r.cfg.NewClient(name, r.extra...)
+	if err != nil {
+		return nil, err
+	}
+	r.clients[name] = c
+	return c, nil
+}
+
+// Default returns the client of the default profile.
+func (r *Registry) Default() (*snow.Client, error) {
+	return r.Client("")
+}
+
+// Names returns the profile names, sorted.
+func (r *Registry) Names() []string {
+	return r.cfg.Names()
+}
@@ -0,0 +1,166 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses the TOML subset used by config files into nested maps:
+// [table] and [dotted.table] headers, key = value pairs with bare, quoted or
+// dotted keys, and string, integer, float and boolean values. Arrays, inline
+// tables, dates and multi-line strings are not supported.
+func parseTOML(data []byte) (map[string]any, error) {
+	root := map[string]any{}
+	current := root
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(stripComment(sc.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: invalid table header %q", n, line)
+			}
+			keys, err := splitKey(line[1 : len(line)-1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			if current, err = subtable(root, keys); err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			continue
+		}
+
+		k, v, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", n)
+		}
+		keys, err := splitKey(k)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		value, err := parseValue(strings.TrimSpace(v))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		table, err := subtable(current, keys[:len(keys)-1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		last := keys[len(keys)-1]
+		if _, exists := table[last]; exists {
+			return nil, fmt.Errorf("line %d: duplicate key %q", n, last)
+		}
+		table[last] = value
+	}
+	return root, sc.Err()
+}
+
+// stripComment removes a # comment that is not inside a string.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == '\\' && quote == '"' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// splitKey splits a dotted key whose parts are bare or quoted.
+func splitKey(s string) ([]string, error) {
+	var keys []string
+	s = strings.TrimSpace(s)
+	for {
+		var key string
+		switch {
+		case strings.HasPrefix(s, `"`), strings.HasPrefix(s, "'"):
+			end := strings.IndexByte(s[1:], s[0])
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated key %q", s)
+			}
+			key, s = s[1:end+1], s[end+2:]
+		default:
+			end := strings.IndexByte(s, '.')
+			if end < 0 {
+				end = len(s)
+			}
+			key, s = strings.TrimSpace(s[:end]), s[end:]
+			if key == "" || strings.Trim(key, "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789_-") != "" {
+				return nil, fmt.Errorf("invalid key %q", key)
+			}
+		}
+		keys = append(keys, key)
+
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return keys, nil
+		}
+		if s[0] != '.' {
+			return nil, fmt.Errorf("invalid key near %q", s)
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+}
+
+func subtable(t map[string]any, keys []string) (map[string]any, error) {
+	for _, k := range keys {
+		switch next := t[k].(type) {
+		case nil:
+			m := map[string]any{}
+			t[k] = m
+			t = m
+		case map[string]any:
+			t = next
+		default:
+			return nil, fmt.Errorf("key %q is not a table", k)
+		}
+	}
+	return t, nil
+}
+
+func parseValue(s string) (any, error) {
+	switch {
+	case s == "":
+		return nil, fmt.Errorf("missing value")
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case s[0] == '"':
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", s)
+		}
+		return v, nil
+	case s[0] == '\'':
+		if len(s) < 2 || s[len(s)-1] != '\'' || strings.Contains(s[1:len(s)-1], "'") {
+			return nil, fmt.Errorf("invalid string %s", s)
+		}
+		return s[1 : len(s)-1], nil
+	}
+
+	clean := strings.ReplaceAll(s, "_", "")
+	if i, err := strconv.ParseInt(clean, 10, 64); err == nil {
+		return i, nil
+	}
+	if f, err := strconv.ParseFloat(clean, 64); err == nil {
+		return f, nil
+	}
+	return nil, fmt.Errorf("unsupported value %s", s)
+}
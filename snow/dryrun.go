@@ -0,0 +1,321 @@
+package snow
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var ErrNilDryRunRecorder = errors.New("dry-run recorder is nil")
+
+// RecordedWrite is a write request captured in dry-run mode.
+type RecordedWrite struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  url.Values      `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+	// SysID is the fake sys_id given to a created record, or the sys_id in
+	// the path of an update or delete.
+	SysID string `json:"sys_id,omitempty"`
+}
+
+// DryRunRecorder collects the writes of a client created with WithDryRun.
+// It is safe for concurrent use.
+type DryRunRecorder struct {
+	mu     sync.Mutex
+	writes []RecordedWrite
+	nextID int
+}
+
+// NewDryRunRecorder returns an empty recorder.
+func NewDryRunRecorder() *DryRunRecorder {
+	return &DryRunRecorder{}
+}
+
+// WithDryRun stops the client from sending POST, PUT, PATCH and DELETE
+// requests. Each one is recorded in rec and answered with a synthesized
+// response echoing the request body as "result", with a fake sys_id for
+// created records, so callers such as table.Client carry on as usual. Reads
+// are still sent and do not see the recorded writes.
+//
+// Two kinds of POST that only read are sent as well: IRE identification
+// (identifyreconcile/query) and the GET requests of a Batch API call, whose
+// responses are merged with the echoes of the others. Any other POST is
+// treated as a write, including scripted REST endpoints that only read.
+func WithDryRun(rec *DryRunRecorder) Option {
+	return func(c *Client) error {
+		if rec == nil {
+			return ErrNilDryRunRecorder
+		}
+		c.dryRun = rec
+		return nil
+	}
+}
+
+// Writes returns the recorded writes in the order they were made.
+func (r *DryRunRecorder) Writes() []RecordedWrite {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]RecordedWrite(nil), r.writes...)
+}
+
+// Reset discards the recorded writes.
+func (r *DryRunRecorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.writes = nil
+}
+
+// WriteJSON writes the recorded writes as an indented JSON array.
+func (r *DryRunRecorder) WriteJSON(w io.Writer) error {
+	writes := r.Writes()
+	if writes == nil {
+		writes = []RecordedWrite{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(writes)
+}
+
+// WritePlan writes the recorded writes as a numbered, human-readable plan.
+func (r *DryRunRecorder) WritePlan(w io.Writer) error {
+	writes := r.Writes()
+	if len(writes) == 0 {
+		_, err := fmt.Fprintln(w, "No changes.")
+		return err
+	}
+
+	for i, rw := range writes {
+		target := rw.Path
+		if len(rw.Query) > 0 {
+			target += "?" + rw.Query.Encode()
+		}
+		line := fmt.Sprintf("%d. %s %s", i+1, rw.Method, target)
+		if rw.Method == http.MethodPost && rw.SysID != "" {
+			line += " (new sys_id " + rw.SysID + ")"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+		if len(rw.Body) == 0 {
+			continue
+		}
+		var body bytes.Buffer
+		if json.Indent(&body, rw.Body, "     ", "  ") != nil {
+			body.Reset()
+			body.Write(rw.Body)
+		}
+		if _, err := fmt.Fprintf(w, "     %s\n", body.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeSysID returns a new sys_id-shaped identifier that cannot clash with
+// real ones, which are hex.
+func (r *DryRunRecorder) fakeSysID() string {
+	r.nextID++
+	return fmt.Sprintf("dryrun%026d", r.nextID)
+}
+
+// isReadOnlyPost reports whether a POST to p only reads, so dry-run mode
+// sends it to the instance: IRE identification without reconciliation.
+func isReadOnlyPost(method, p string) bool {
+	segs := apiSegments(p)
+	return strings.EqualFold(method, http.MethodPost) && len(segs) == 2 && segs[0] == "identifyreconcile" && segs[1] == "query"
+}
+
+// respond records req and synthesizes its response. p is the API path. send
+// is used for the GET requests of a Batch API call.
+func (r *DryRunRecorder) respond(req *http.Request, p string, send func(*http.Request) (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		body = b
+	}
+
+	method := strings.ToUpper(req.Method)
+	rw := RecordedWrite{Method: method, Path: p, Body: body}
+	if q := req.URL.Query(); len(q) > 0 {
+		rw.Query = q
+	}
+	if isBatchPath(p) {
+		return r.respondBatch(req, rw, send)
+	}
+
+	r.mu.Lock()
+	status, raw := http.StatusOK, []byte(nil)
+	switch {
+	case method == http.MethodDelete:
+		status = http.StatusNoContent
+		rw.SysID = lastSegment(p)
+	case method == http.MethodPost:
+		status = http.StatusCreated
+		if rw.SysID = bodySysID(body); rw.SysID == "" {
+			rw.SysID = r.fakeSysID()
+		}
+		raw = echoResult(body, rw.SysID)
+	default:
+		rw.SysID = lastSegment(p)
+		raw = echoResult(body, rw.SysID)
+	}
+	r.writes = append(r.writes, rw)
+	r.mu.Unlock()
+
+	return dryRunResponse(req, status, raw), raw, nil
+}
+
+type dryRunBatchRequest struct {
+	ID     string `json:"id"`
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body"`
+}
+
+type dryRunServiced struct {
+	ID         string `json:"id"`
+	StatusCode int    `json:"status_code"`
+	Body       string `json:"body"`
+}
+
+// respondBatch answers a Batch API call. Its GET requests are sent to the
+// instance as a batch of their own; the others are echoed, and only they are
+// recorded.
+func (r *DryRunRecorder) respondBatch(req *http.Request, rw RecordedWrite, send func(*http.Request) (*http.Response, []byte, error)) (*http.Response, []byte, error) {
+	var in map[string]json.RawMessage
+	var all []json.RawMessage
+	if json.Unmarshal(rw.Body, &in) != nil || json.Unmarshal(in["rest_requests"], &all) != nil {
+		r.mu.Lock()
+		r.writes = append(r.writes, rw)
+		r.mu.Unlock()
+		return dryRunResponse(req, http.StatusOK, nil), nil, nil
+	}
+
+	var reads, writes []json.RawMessage
+	var writeReqs []dryRunBatchRequest
+	for _, raw := range all {
+		var rr dryRunBatchRequest
+		if json.Unmarshal(raw, &rr) == nil && strings.EqualFold(rr.Method, http.MethodGet) {
+			reads = append(reads, raw)
+			continue
+		}
+		writes = append(writes, raw)
+		writeReqs = append(writeReqs, rr)
+	}
+
+	out := struct {
+		BatchRequestID     string            `json:"batch_request_id"`
+		ServicedRequests   []json.RawMessage `json:"serviced_requests"`
+		UnservicedRequests []string          `json:"unserviced_requests,omitempty"`
+	}{ServicedRequests: []json.RawMessage{}}
+	json.Unmarshal(in["batch_request_id"], &out.BatchRequestID)
+
+	if len(reads) > 0 {
+		in["rest_requests"], _ = json.Marshal(reads)
+		readBody, err := json.Marshal(in)
+		if err != nil {
+			return nil, nil, err
+		}
+		sub := req.Clone(req.Context())
+		sub.Body = io.NopCloser(bytes.NewReader(readBody))
+		sub.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(readBody)), nil }
+		sub.ContentLength = int64(len(readBody))
+
+		resp, raw, err := send(sub)
+		if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 || len(writes) == 0 {
+			return resp, raw, err
+		}
+		if err := json.Unmarshal(raw, &out); err != nil {
+			return resp, raw, err
+		}
+	}
+
+	r.mu.Lock()
+	for _, rr := range writeReqs {
+		out.ServicedRequests = append(out.ServicedRequests, r.echoBatchRequest(rr))
+	}
+	if len(reads) > 0 {
+		in["rest_requests"], _ = json.Marshal(writes)
+		rw.Body, _ = json.Marshal(in)
+	}
+	r.writes = append(r.writes, rw)
+	r.mu.Unlock()
+
+	raw, _ := json.Marshal(out)
+	return dryRunResponse(req, http.StatusOK, raw), raw, nil
+}
+
+// echoBatchRequest services a Batch API request with an echo of its own body.
+// Called with r.mu held.
+func (r *DryRunRecorder) echoBatchRequest(rr dryRunBatchRequest) json.RawMessage {
+	sysID := ""
+	if u, err := url.Parse(rr.URL); err == nil {
+		sysID = lastSegment(u.Path)
+	}
+	inner, _ := base64.StdEncoding.DecodeString(rr.Body)
+	status := http.StatusOK
+	if strings.EqualFold(rr.Method, http.MethodPost) {
+		if status, sysID = http.StatusCreated, bodySysID(inner); sysID == "" {
+			sysID = r.fakeSysID()
+		}
+	}
+	raw, _ := json.Marshal(dryRunServiced{
+		ID:         rr.ID,
+		StatusCode: status,
+		Body:       base64.StdEncoding.EncodeToString(echoResult(inner, sysID)),
+	})
+	return raw
+}
+
+func dryRunResponse(req *http.Request, status int, raw []byte) *http.Response {
+	return &http.Response{
+		Status:     strconv.Itoa(status) + " " + http.StatusText(status),
+		StatusCode: status,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(raw)),
+		Request:    req,
+	}
+}
+
+// echoResult wraps body as {"result": body}, adding sys_id to objects that
+// do not have one.
+func echoResult(body []byte, sysID string) []byte {
+	var obj map[string]any
+	if json.Unmarshal(body, &obj) != nil || obj == nil {
+		obj = map[string]any{}
+	}
+	if _, ok := obj["sys_id"]; !ok && sysID != "" {
+		obj["sys_id"] = sysID
+	}
+	raw, _ := json.Marshal(map[string]any{"result": obj})
+	return raw
+}
+
+func bodySysID(body []byte) string {
+	var rec struct {
+		SysID string `json:"sys_id"`
+	}
+	json.Unmarshal(body, &rec)
+	return rec.SysID
+}
+
+func lastSegment(p string) string {
+	p = strings.TrimRight(p, "/")
+	return p[strings.LastIndex(p, "/")+1:]
+}
@@ -0,0 +1,203 @@
+package snow
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("%s %s sent in dry-run mode", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"result":[{"sys_id":"a"}]}`))
+	}))
+	defer srv.Close()
+
+	rec := NewDryRunRecorder()
+	c, err := NewClient(WithInstanceURL(srv.URL), WithBasicAuth("admin", "secret"), WithDryRun(rec))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	do := func(method, p string, query map[string][]string, body, out any) *http.Response {
+		t.Helper()
+		req, err := c.NewRequest(ctx, method, p, query, body)
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+		resp, err := c.DoWithResponse(req, out)
+		if err != nil {
+			t.Fatalf("Do(%s %s) error = %v", method, p, err)
+		}
+		return resp
+	}
+
+	var list struct{ Result []map[string]any }
+	do(http.MethodGet, "/api/now/table/incident", nil, nil, &list)
+	if len(list.Result) != 1 {
+		t.Fatalf("GET result = %v", list.Result)
+	}
+
+	var created struct{ Result map[string]any }
+	resp := do(http.MethodPost, "/api/now/table/incident", nil, map[string]string{"short_description": "Printer"}, &created)
+	if resp.StatusCode != http.StatusCreated || created.Result["short_description"] != "Printer" || created.Result["sys_id"] == "" {
+		t.Fatalf("POST = %d %v", resp.StatusCode, created.Result)
+	}
+
+	var updated struct{ Result map[string]any }
+	do(http.MethodPatch, "/api/now/table/incident/a", map[string][]string{"sysparm_fields": {"sys_id"}}, map[string]string{"state": "2"}, &updated)
+	if updated.Result["sys_id"] != "a" || updated.Result["state"] != "2" {
+		t.Fatalf("PATCH result = %v", updated.Result)
+	}
+
+	if resp := do(http.MethodDelete, "/api/now/table/incident/b", nil, nil, nil); resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("DELETE status = %d", resp.StatusCode)
+	}
+
+	var batch struct {
+		ServicedRequests []struct {
+			ID         string `json:"id"`
+			StatusCode int    `json:"status_code"`
+			Body       string `json:"body"`
+		} `json:"serviced_requests"`
+	}
+	do(http.MethodPost, "/api/now/v1/batch", nil, map[string]any{
+		"batch_request_id": "1",
+		"rest_requests": []map[string]string{{
+			"id": "0", "method": "PATCH", "url": "/api/now/table/incident/c",
+			"body": base64.StdEncoding.EncodeToString([]byte(`{"state":"6"}`)),
+		}},
+	}, &batch)
+	if len(batch.ServicedRequests) != 1 || batch.ServicedRequests[0].StatusCode != http.StatusOK {
+		t.Fatalf("batch = %+v", batch)
+	}
+	if body, _ := base64.StdEncoding.DecodeString(batch.ServicedRequests[0].Body); string(body) != `{"result":{"state":"6","sys_id":"c"}}` {
+		t.Fatalf("batch body = %s", body)
+	}
+
+	writes := rec.Writes()
+	if len(writes) != 4 {
+		t.Fatalf("Writes() = %+v", writes)
+	}
+	if w := writes[1]; w.Method != http.MethodPatch || w.Path != "/api/now/table/incident/a" || w.Query.Get("sysparm_fields") != "sys_id" ||
+		string(w.Body) != `{"state":"2"}` || w.SysID != "a" {
+		t.Errorf("writes[1] = %+v", w)
+	}
+	if writes[0].SysID != created.Result["sys_id"] {
+		t.Errorf("writes[0].SysID = %q, want %v", writes[0].SysID, created.Result["sys_id"])
+	}
+
+	var plan bytes.Buffer
+	if err := rec.WritePlan(&plan); err != nil {
+		t.Fatalf("WritePlan() error = %v", err)
+	}
+	if !strings.Contains(plan.String(), "3. DELETE /api/now/table/incident/b\n") ||
+		!strings.Contains(plan.String(), "1. POST /api/now/table/incident (new sys_id "+writes[0].SysID+")") {
+		t.Errorf("WritePlan() =\n%s", plan.String())
+	}
+
+	var exported bytes.Buffer
+	if err := rec.WriteJSON(&exported); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var decoded []RecordedWrite
+	if err := json.Unmarshal(exported.Bytes(), &decoded); err != nil || len(decoded) != 4 || decoded[2].SysID != "b" {
+		t.Fatalf("WriteJSON() = %s, %v", exported.String(), err)
+	}
+}
+
+func TestDryRunBatchReads(t *testing.T) {
+	var sent []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/now/v1/batch" {
+			t.Errorf("%s %s sent in dry-run mode", r.Method, r.URL.Path)
+			return
+		}
+		var in struct {
+			RestRequests []struct {
+				ID     string `json:"id"`
+				Method string `json:"method"`
+			} `json:"rest_requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+			t.Errorf("decode body: %v", err)
+			return
+		}
+		var serviced []string
+		for _, rr := range in.RestRequests {
+			sent = append(sent, rr.Method+" "+rr.ID)
+			serviced = append(serviced, `{"id":"`+rr.ID+`","status_code":200,"body":"`+
+				base64.StdEncoding.EncodeToString([]byte(`{"result":{"sys_id":"a","state":"1"}}`))+`"}`)
+		}
+		_, _ = w.Write([]byte(`{"batch_request_id":"1","serviced_requests":[` + strings.Join(serviced, ",") + `]}`))
+	}))
+	defer srv.Close()
+
+	rec := NewDryRunRecorder()
+	c, err := NewClient(WithInstanceURL(srv.URL), WithBasicAuth("admin", "secret"), WithDryRun(rec))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+
+	type serviced struct {
+		ID         string `json:"id"`
+		StatusCode int    `json:"status_code"`
+		Body       string `json:"body"`
+	}
+	batch := func(requests ...map[string]string) map[string]string {
+		t.Helper()
+		req, err := c.NewRequest(context.Background(), http.MethodPost, "/api/now/v1/batch", nil, map[string]any{
+			"batch_request_id": "1",
+			"rest_requests":    requests,
+		})
+		if err != nil {
+			t.Fatalf("NewRequest() error = %v", err)
+		}
+		var out struct {
+			ServicedRequests []serviced `json:"serviced_requests"`
+		}
+		if err := c.Do(req, &out); err != nil {
+			t.Fatalf("Do() error = %v", err)
+		}
+		bodies := map[string]string{}
+		for _, s := range out.ServicedRequests {
+			body, _ := base64.StdEncoding.DecodeString(s.Body)
+			bodies[s.ID] = string(body)
+		}
+		return bodies
+	}
+
+	get := map[string]string{"id": "0", "method": "GET", "url": "/api/now/table/incident/a"}
+	patch := map[string]string{
+		"id": "1", "method": "PATCH", "url": "/api/now/table/incident/b",
+		"body": base64.StdEncoding.EncodeToString([]byte(`{"state":"6"}`)),
+	}
+
+	got := batch(get, patch)
+	if len(got) != 2 || got["0"] != `{"result":{"sys_id":"a","state":"1"}}` || got["1"] != `{"result":{"state":"6","sys_id":"b"}}` {
+		t.Fatalf("mixed batch = %v", got)
+	}
+	if strings.Join(sent, ",") != "GET 0" {
+		t.Fatalf("sent = %v, want only the GET", sent)
+	}
+	writes := rec.Writes()
+	if len(writes) != 1 || strings.Contains(string(writes[0].Body), "GET") || !strings.Contains(string(writes[0].Body), "PATCH") {
+		t.Fatalf("Writes() = %+v", writes)
+	}
+
+	rec.Reset()
+	if got := batch(get); len(got) != 1 || got["0"] == "" {
+		t.Fatalf("read batch = %v", got)
+	}
+	if writes := rec.Writes(); len(writes) != 0 {
+		t.Fatalf("Writes() after read batch = %+v", writes)
+	}
+}
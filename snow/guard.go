@@ -0,0 +1,165 @@
+package snow
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	ErrReadOnly           = errors.New("client is read-only")
+	ErrProtectedTable     = errors.New("write to protected table requires an override token")
+	ErrEmptyOverrideToken = errors.New("override token cannot be empty")
+)
+
+// WriteRefusedError is returned by NewRequest for a write the client's
+// safeguards do not allow. It wraps ErrReadOnly or ErrProtectedTable.
+type WriteRefusedError struct {
+	Method string
+	Path   string
+	Table  string // set for ErrProtectedTable
+	Err    error
+}
+
+func (e *WriteRefusedError) Error() string {
+	if e.Table != "" {
+		return fmt.Sprintf("%s %s refused: %v (%s)", e.Method, e.Path, e.Err, e.Table)
+	}
+	return fmt.Sprintf("%s %s refused: %v", e.Method, e.Path, e.Err)
+}
+
+func (e *WriteRefusedError) Unwrap() error { return e.Err }
+
+// WithReadOnly makes NewRequest refuse every POST, PUT, PATCH and DELETE
+// request with a *WriteRefusedError wrapping ErrReadOnly, including POST
+// endpoints that only read, such as the Batch API.
+func WithReadOnly() Option {
+	return func(c *Client) error {
+		c.readOnly = true
+		return nil
+	}
+}
+
+// WithProtectedTables makes NewRequest refuse writes to the given tables
+// through the Table, CMDB Instance and Batch APIs unless the request context
+// carries token (see AllowProtectedWrites). For the CMDB Instance API the
+// table is the CI class in the path; class hierarchy is not taken into account.
+func WithProtectedTables(token string, tables ...string) Option {
+	return func(c *Client) error {
+		if strings.TrimSpace(token) == "" {
+			return ErrEmptyOverrideToken
+		}
+		if c.protected == nil {
+			c.protected = map[string]bool{}
+		}
+		for _, t := range tables {
+			if t = strings.TrimSpace(t); t != "" {
+				c.protected[t] = true
+			}
+		}
+		c.overrideToken = token
+		return nil
+	}
+}
+
+type overrideKey struct{}
+
+// AllowProtectedWrites returns a context that lets requests built with it
+// write to the client's protected tables, if token matches the one given to
+// WithProtectedTables.
+func AllowProtectedWrites(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, overrideKey{}, token)
+}
+
+// checkWrite applies the read-only and protected-table safeguards.
+func (c *Client) checkWrite(ctx context.Context, method, p string, body []byte) error {
+	if !isWriteMethod(method) {
+		return nil
+	}
+
+	if c.readOnly {
+		return &WriteRefusedError{Method: method, Path: p, Err: ErrReadOnly}
+	}
+	if len(c.protected) == 0 {
+		return nil
+	}
+	if token, _ := ctx.Value(overrideKey{}).(string); subtle.ConstantTimeCompare([]byte(token), []byte(c.overrideToken)) == 1 {
+		return nil
+	}
+
+	for _, t := range writtenTables(p, body) {
+		if c.protected[t] {
+			return &WriteRefusedError{Method: method, Path: p, Table: t, Err: ErrProtectedTable}
+		}
+	}
+	return nil
+}
+
+// writtenTables returns the tables a write request targets.
+func writtenTables(p string, body []byte) []string {
+	if t := pathTable(p); t != "" {
+		return []string{t}
+	}
+	if !isBatchPath(p) {
+		return nil
+	}
+
+	var batch struct {
+		RestRequests []struct {
+			Method string `json:"method"`
+			URL    string `json:"url"`
+		} `json:"rest_requests"`
+	}
+	if json.Unmarshal(body, &batch) != nil {
+		return nil
+	}
+	var tables []string
+	for _, rr := range batch.RestRequests {
+		if strings.EqualFold(rr.Method, http.MethodGet) {
+			continue
+		}
+		u, err := url.Parse(rr.URL)
+		if err != nil {
+			continue
+		}
+		if t := pathTable(u.Path); t != "" {
+			tables = append(tables, t)
+		}
+	}
+	return tables
+}
+
+// apiSegments splits an /api/now path into the segments after the optional
+// version, e.g. "/api/now/v2/table/incident" into ["table", "incident"].
+func apiSegments(p string) []string {
+	segs := strings.Split(strings.Trim(p, "/"), "/")
+	if len(segs) < 3 || segs[0] != "api" || segs[1] != "now" {
+		return nil
+	}
+	segs = segs[2:]
+	if len(segs) > 0 && len(segs[0]) > 1 && segs[0][0] == 'v' && strings.Trim(segs[0][1:], "0123456789") == "" {
+		segs = segs[1:]
+	}
+	return segs
+}
+
+func pathTable(p string) string {
+	segs := apiSegments(p)
+	switch {
+	case len(segs) >= 2 && segs[0] == "table":
+		return segs[1]
+	case len(segs) >= 3 && segs[0] == "cmdb" && segs[1] == "instance":
+		return segs[2]
+	}
+	return ""
+}
+
+func isBatchPath(p string) bool {
+	segs := apiSegments(p)
+	return len(segs) == 1 && segs[0] == "batch"
+}
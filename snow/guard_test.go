@@ -0,0 +1,82 @@
+package snow
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	c, err := NewClient(WithInstanceURL("https://dev.service-now.com"), WithBasicAuth("admin", "secret"), WithReadOnly())
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	if _, err := c.NewRequest(ctx, http.MethodGet, "/api/now/table/incident", nil, nil); err != nil {
+		t.Fatalf("NewRequest(GET) error = %v", err)
+	}
+	_, err = c.NewRequest(ctx, http.MethodPatch, "/api/now/table/incident/1", nil, map[string]string{"state": "2"})
+	var refused *WriteRefusedError
+	if !errors.As(err, &refused) || !errors.Is(err, ErrReadOnly) || refused.Method != http.MethodPatch {
+		t.Fatalf("NewRequest(PATCH) error = %v, want ErrReadOnly", err)
+	}
+}
+
+func TestProtectedTables(t *testing.T) {
+	if _, err := NewClient(WithProtectedTables(" ", "sys_user")); !errors.Is(err, ErrEmptyOverrideToken) {
+		t.Fatalf("NewClient() error = %v, want ErrEmptyOverrideToken", err)
+	}
+
+	c, err := NewClient(
+		WithInstanceURL("https://dev.service-now.com"),
+		WithBasicAuth("admin", "secret"),
+		WithProtectedTables("i-know", "sys_user", "cmdb_ci_server"),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		path   string
+		body   any
+		table  string
+	}{
+		{"read", ctx, http.MethodGet, "/api/now/table/sys_user", nil, ""},
+		{"unprotected write", ctx, http.MethodPost, "/api/now/table/incident", map[string]string{}, ""},
+		{"table write", ctx, http.MethodDelete, "/api/now/table/sys_user/1", nil, "sys_user"},
+		{"versioned path", ctx, http.MethodPatch, "/api/now/v2/table/sys_user/1", map[string]string{}, "sys_user"},
+		{"cmdb instance", ctx, http.MethodPost, "/api/now/cmdb/instance/cmdb_ci_server", map[string]string{}, "cmdb_ci_server"},
+		{"batch", ctx, http.MethodPost, "/api/now/v1/batch", map[string]any{
+			"rest_requests": []map[string]string{
+				{"method": "GET", "url": "/api/now/table/sys_user/1"},
+				{"method": "PATCH", "url": "/api/now/table/sys_user/2?sysparm_fields=sys_id"},
+			},
+		}, "sys_user"},
+		{"batch reads", ctx, http.MethodPost, "/api/now/v1/batch", map[string]any{
+			"rest_requests": []map[string]string{{"method": "GET", "url": "/api/now/table/sys_user/1"}},
+		}, ""},
+		{"override", AllowProtectedWrites(ctx, "i-know"), http.MethodDelete, "/api/now/table/sys_user/1", nil, ""},
+		{"wrong override", AllowProtectedWrites(ctx, "guess"), http.MethodDelete, "/api/now/table/sys_user/1", nil, "sys_user"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.NewRequest(tt.ctx, tt.method, tt.path, nil, tt.body)
+			if tt.table == "" {
+				if err != nil {
+					t.Fatalf("NewRequest() error = %v", err)
+				}
+				return
+			}
+			var refused *WriteRefusedError
+			if !errors.As(err, &refused) || !errors.Is(err, ErrProtectedTable) || refused.Table != tt.table {
+				t.Fatalf("NewRequest() error = %v, want ErrProtectedTable for %s", err, tt.table)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Package snowtest holds helpers shared by the tests of the snow subpackages.
+package snowtest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+// NewClient returns a snow client for a test server running h, closed when
+// the test ends. opts are applied after the instance URL and credentials.
+func NewClient(t testing.TB, h http.HandlerFunc, opts ...snow.Option) *snow.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	sc, err := snow.NewClient(append([]snow.Option{snow.WithInstanceURL(srv.URL), snow.WithBasicAuth("admin", "secret")}, opts...)...)
+	if err != nil {
+		t.Fatalf("snow.NewClient() error = %v", err)
+	}
+	return sc
+}
@@ -0,0 +1,94 @@
+package knowledge
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+const articlesAPIPath = "/api/sn_km_api/knowledge/articles"
+
+// Client is a Knowledge Management API client (/api/sn_km_api/knowledge).
+type Client struct {
+	r snow.Requester
+}
+
+func New(r snow.Requester) (*Client, error) {
+	if r == nil {
+		return nil, table.ErrNilRequester
+	}
+	return &Client{r: r}, nil
+}
+
+// Search returns relevance-ranked articles matching opts.Query. Use
+// Meta.End as the next Offset while HasMore reports true.
+func (c *Client) Search(ctx context.Context, opts *SearchOptions) (*SearchResult, error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+	return c.list(ctx, articlesAPIPath, q)
+}
+
+// Featured returns the featured articles.
+func (c *Client) Featured(ctx context.Context, opts *ListOptions) (*SearchResult, error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+	return c.list(ctx, path.Join(articlesAPIPath, "featured"), q)
+}
+
+// MostViewed returns the most viewed articles.
+func (c *Client) MostViewed(ctx context.Context, opts *ListOptions) (*SearchResult, error) {
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+	return c.list(ctx, path.Join(articlesAPIPath, "most_viewed"), q)
+}
+
+// GetArticle returns an article by sys_id or number (e.g. "KB0000011"),
+// including its content and attachments.
+func (c *Client) GetArticle(ctx context.Context, id string, opts *GetOptions) (*ArticleDetail, error) {
+	id = strings.TrimSpace(id)
+	if id == "" || strings.ContainsAny(id, `/\\`) {
+		return nil, table.ErrInvalidSysID
+	}
+
+	q := url.Values{}
+	if err := opts.apply(q); err != nil {
+		return nil, err
+	}
+
+	var out resultOne[ArticleDetail]
+	if err := c.do(ctx, path.Join(articlesAPIPath, id), q, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) list(ctx context.Context, p string, q url.Values) (*SearchResult, error) {
+	var out resultOne[SearchResult]
+	if err := c.do(ctx, p, q, &out); err != nil {
+		return nil, err
+	}
+	return &out.Result, nil
+}
+
+func (c *Client) do(ctx context.Context, p string, q url.Values, out any) error {
+	if c == nil || c.r == nil {
+		return table.ErrNilRequester
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodGet, p, q, nil)
+	if err != nil {
+		return err
+	}
+	return c.r.Do(req, out)
+}
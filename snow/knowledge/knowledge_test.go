@@ -0,0 +1,149 @@
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/internal/snowtest"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	c, err := New(snowtest.NewClient(t, h))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
+
+func TestSearch(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/sn_km_api/knowledge/articles" || q.Get("query") != "vpn" ||
+			q.Get("fields") != "short_description,kb_category" || q.Get("kb") != "kb1" || q.Get("limit") != "2" {
+			t.Errorf("request = %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"result":{"meta":{"start":0,"end":2,"count":5,"status":{"code":200}},"articles":[
+			{"id":"a1","number":"KB0000001","title":"VPN setup","score":12.5,"fields":{"kb_category":{"display_value":"Network","value":"c1"}}},
+			{"id":"a2","number":"KB0000002","title":"VPN errors","score":9.1}
+		]}}`))
+	})
+
+	res, err := c.Search(context.Background(), &SearchOptions{
+		Query:  "vpn",
+		Fields: []string{"short_description", "kb_category"},
+		KB:     []string{"kb1"},
+		Limit:  table.Int(2),
+	})
+	if err != nil {
+		t.Fatalf("Search() error = %v", err)
+	}
+
+	if res.Meta.Count != 5 || res.Meta.Status.Code != 200 || !res.HasMore() {
+		t.Fatalf("Search() meta = %+v", res.Meta)
+	}
+	if len(res.Articles) != 2 || res.Articles[0].Score != 12.5 || res.Articles[0].Fields["kb_category"].DisplayValue != "Network" {
+		t.Fatalf("Search() articles = %+v", res.Articles)
+	}
+}
+
+func TestGetArticleBySysID(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.Method != http.MethodGet || r.URL.Path != "/api/sn_km_api/knowledge/articles/0b48fd75474321009db4b5b08b9a71c2" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if q.Get("fields") != "kb_category" || q.Get("search_id") != "tsq1" || q.Get("search_rank") != "3" || q.Get("update_view") != "true" {
+			t.Errorf("query = %v", q)
+		}
+		_, _ = w.Write([]byte(`{"result":{"sys_id":"0b48fd75474321009db4b5b08b9a71c2","number":"KB0000011","title":"Reset your password",
+			"content":"<p>Open the portal.</p>","template":false,"display_attachments":false,"attachments":[],
+			"fields":{"kb_category":{"name":"kb_category","label":"Category","type":"reference","value":"c1","display_value":"Accounts"}}}}`))
+	})
+
+	art, err := c.GetArticle(context.Background(), "0b48fd75474321009db4b5b08b9a71c2", &GetOptions{
+		Fields:     []string{"kb_category"},
+		SearchID:   "tsq1",
+		SearchRank: table.Int(3),
+		UpdateView: table.Bool(true),
+	})
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	if art.Number != "KB0000011" || art.Content != "<p>Open the portal.</p>" || art.Fields["kb_category"].DisplayValue != "Accounts" {
+		t.Fatalf("GetArticle() = %+v", art)
+	}
+
+	if _, err := c.GetArticle(context.Background(), "kb/1", nil); !errors.Is(err, table.ErrInvalidSysID) {
+		t.Fatalf("GetArticle() error = %v, want %v", err, table.ErrInvalidSysID)
+	}
+}
+
+func TestGetArticleByNumberWithAttachments(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/sn_km_api/knowledge/articles/KB0000012" || r.URL.Query().Get("language") != "de" {
+			t.Errorf("request = %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"result":{"sys_id":"a12","number":"KB0000012","title":"VPN client","display_attachments":true,"attachments":[
+			{"sys_id":"att1","file_name":"vpn.pdf","content_type":"application/pdf","size_bytes":"52311","state":"available"},
+			{"sys_id":"att2","file_name":"setup.png","content_type":"image/png","size_bytes":"1024","state":"available"}
+		]}}`))
+	})
+
+	art, err := c.GetArticle(context.Background(), " KB0000012 ", &GetOptions{Language: "de"})
+	if err != nil {
+		t.Fatalf("GetArticle() error = %v", err)
+	}
+	if art.SysID != "a12" || !art.DisplayAttachments || len(art.Attachments) != 2 {
+		t.Fatalf("GetArticle() = %+v", art)
+	}
+	if a := art.Attachments[0]; a.FileName != "vpn.pdf" || a.ContentType != "application/pdf" || a.SizeBytes != "52311" {
+		t.Fatalf("GetArticle() attachment = %+v", a)
+	}
+}
+
+func TestFeaturedAndMostViewed(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+		call func(*Client, context.Context, *ListOptions) (*SearchResult, error)
+	}{
+		{"featured", "/api/sn_km_api/knowledge/articles/featured", (*Client).Featured},
+		{"most viewed", "/api/sn_km_api/knowledge/articles/most_viewed", (*Client).MostViewed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				q := r.URL.Query()
+				if r.URL.Path != tt.path || q.Get("kb") != "kb1,kb2" || q.Get("fields") != "sys_view_count" ||
+					q.Get("limit") != "1" || q.Get("offset") != "1" || q.Has("query") {
+					t.Errorf("request = %s", r.URL)
+				}
+				_, _ = w.Write([]byte(`{"result":{"meta":{"start":1,"end":2,"count":2,"kb":"kb1,kb2","status":{"code":200}},"articles":[
+					{"id":"a2","number":"KB0000002","title":"VPN errors","fields":{"sys_view_count":{"name":"sys_view_count","value":42,"display_value":"42"}}}
+				]}}`))
+			})
+
+			res, err := tt.call(c, context.Background(), &ListOptions{
+				Fields: []string{"sys_view_count"},
+				KB:     []string{"kb1", " ", "kb2"},
+				Limit:  table.Int(1),
+				Offset: table.Int(1),
+			})
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			if res.Meta.Start != 1 || res.Meta.KB != "kb1,kb2" || res.HasMore() {
+				t.Fatalf("meta = %+v", res.Meta)
+			}
+			if len(res.Articles) != 1 || res.Articles[0].Fields["sys_view_count"].Value != float64(42) {
+				t.Fatalf("articles = %+v", res.Articles)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package knowledge
+
+// SearchResult is a page of articles with the search metadata.
+type SearchResult struct {
+	Meta     SearchMeta `json:"meta"`
+	Articles []Article  `json:"articles"`
+}
+
+// HasMore reports whether more articles match than were returned so far.
+func (r *SearchResult) HasMore() bool {
+	return r != nil && r.Meta.End < r.Meta.Count
+}
+
+// SearchMeta describes a search: the effective parameters, the total number
+// of matches (Count) and the index after the last returned article (End).
+type SearchMeta struct {
+	Start     int          `json:"start"`
+	End       int          `json:"end"`
+	Count     int          `json:"count"`
+	Fields    string       `json:"fields"`
+	Query     string       `json:"query"`
+	Filter    string       `json:"filter"`
+	KB        string       `json:"kb"`
+	Language  string       `json:"language"`
+	TSQueryID string       `json:"ts_query_id"`
+	Status    SearchStatus `json:"status"`
+}
+
+// SearchStatus is the status of a search.
+type SearchStatus struct {
+	Code int `json:"code"`
+}
+
+// Article is a search hit. Score is the relevance score of the hit; Fields
+// holds the fields requested with SearchOptions.Fields.
+type Article struct {
+	ID      string                  `json:"id"`
+	Number  string                  `json:"number"`
+	Title   string                  `json:"title"`
+	Snippet string                  `json:"snippet"`
+	Score   float64                 `json:"score"`
+	Link    string                  `json:"link"`
+	Fields  map[string]ArticleField `json:"fields,omitempty"`
+}
+
+// ArticleField is a requested field of an article.
+type ArticleField struct {
+	Name         string `json:"name"`
+	Label        string `json:"label"`
+	Type         string `json:"type"`
+	Value        any    `json:"value"`
+	DisplayValue string `json:"display_value"`
+}
+
+// ArticleDetail is a full article with its content and attachments.
+type ArticleDetail struct {
+	SysID              string                  `json:"sys_id"`
+	Number             string                  `json:"number"`
+	Title              string                  `json:"title"`
+	ShortDescription   string                  `json:"short_description"`
+	Content            string                  `json:"content"`
+	Template           bool                    `json:"template"`
+	DisplayAttachments bool                    `json:"display_attachments"`
+	Attachments        []Attachment            `json:"attachments"`
+	EmbeddedContent    []any                   `json:"embedded_content,omitempty"`
+	Fields             map[string]ArticleField `json:"fields,omitempty"`
+}
+
+// Attachment is an attachment of an article.
+type Attachment struct {
+	SysID       string `json:"sys_id"`
+	FileName    string `json:"file_name"`
+	ContentType string `json:"content_type"`
+	SizeBytes   string `json:"size_bytes"`
+	State       string `json:"state"`
+}
+
+// Internal envelope types matching the ServiceNow result wrapper.
+type resultOne[T any] struct {
+	Result T `json:"result"`
+}
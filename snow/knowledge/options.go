@@ -0,0 +1,120 @@
+package knowledge
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// SearchOptions are the parameters of Search.
+type SearchOptions struct {
+	Query    string   // Free text, ranked by relevance
+	Fields   []string // kb_knowledge fields to return with each article
+	KB       []string // kb_knowledge_base sys_ids to search
+	Filter   string   // Encoded query (build with table.QueryBuilder)
+	Language string   // e.g. "en"; "all" searches every language
+	Limit    *int
+	Offset   *int
+}
+
+// ListOptions are the parameters of the featured and most viewed lists.
+type ListOptions struct {
+	Fields   []string
+	KB       []string
+	Language string
+	Limit    *int
+	Offset   *int
+}
+
+// GetOptions are the parameters of GetArticle.
+type GetOptions struct {
+	Fields   []string
+	Language string
+	// SearchID and SearchRank attribute the view to a search result.
+	SearchID   string
+	SearchRank *int
+	// UpdateView increments the view count of the article.
+	UpdateView *bool
+}
+
+func (o *SearchOptions) apply(q url.Values) error {
+	if o == nil {
+		return nil
+	}
+
+	if query := strings.TrimSpace(o.Query); query != "" {
+		q.Set("query", query)
+	}
+	if filter := strings.TrimSpace(o.Filter); filter != "" {
+		q.Set("filter", filter)
+	}
+	return applyList(q, o.Fields, o.KB, o.Language, o.Limit, o.Offset)
+}
+
+func (o *ListOptions) apply(q url.Values) error {
+	if o == nil {
+		return nil
+	}
+	return applyList(q, o.Fields, o.KB, o.Language, o.Limit, o.Offset)
+}
+
+func (o *GetOptions) apply(q url.Values) error {
+	if o == nil {
+		return nil
+	}
+
+	if fields := joinList(o.Fields); fields != "" {
+		q.Set("fields", fields)
+	}
+	if lang := strings.TrimSpace(o.Language); lang != "" {
+		q.Set("language", lang)
+	}
+	if id := strings.TrimSpace(o.SearchID); id != "" {
+		q.Set("search_id", id)
+	}
+	if o.SearchRank != nil {
+		q.Set("search_rank", strconv.Itoa(*o.SearchRank))
+	}
+	if o.UpdateView != nil {
+		q.Set("update_view", strconv.FormatBool(*o.UpdateView))
+	}
+	return nil
+}
+
+func applyList(q url.Values, fields, kb []string, language string, limit, offset *int) error {
+	if limit != nil && *limit < 0 {
+		return table.ErrInvalidLimit
+	}
+	if offset != nil && *offset < 0 {
+		return table.ErrInvalidOffset
+	}
+
+	if f := joinList(fields); f != "" {
+		q.Set("fields", f)
+	}
+	if k := joinList(kb); k != "" {
+		q.Set("kb", k)
+	}
+	if lang := strings.TrimSpace(language); lang != "" {
+		q.Set("language", lang)
+	}
+	if limit != nil {
+		q.Set("limit", strconv.Itoa(*limit))
+	}
+	if offset != nil {
+		q.Set("offset", strconv.Itoa(*offset))
+	}
+	return nil
+}
+
+func joinList(values []string) string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return strings.Join(out, ",")
+}
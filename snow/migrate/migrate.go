@@ -0,0 +1,501 @@
+// Package migrate copies records between instances, e.g. reference data from
+// production to a development instance.
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+var (
+	ErrNoTables        = errors.New("no tables to migrate")
+	ErrDuplicateTable  = errors.New("table listed more than once")
+	ErrKeyRequired     = errors.New("table requires Key unless KeepSysID is set")
+	ErrKeyNotRead      = errors.New("key field is not in Fields or is excluded")
+	ErrInvalidRef      = errors.New("reference requires a table and a key")
+	ErrDependencyCycle = errors.New("tables reference each other in a cycle")
+	ErrAmbiguousKey    = errors.New("key matches more than one target record")
+)
+
+// Migrator copies records from a source to a target instance. It remembers
+// the target sys_id of every record it copied, so references to records
+// migrated earlier in the same run resolve without a lookup.
+type Migrator struct {
+	source snow.Requester
+	target snow.Requester
+	opts   Options
+
+	plan   map[string]Table
+	mapped map[string]string // "table/source sys_id" -> target sys_id
+}
+
+func New(source, target snow.Requester, opts *Options) (*Migrator, error) {
+	if source == nil || target == nil {
+		return nil, table.ErrNilRequester
+	}
+	m := &Migrator{source: source, target: target, mapped: map[string]string{}}
+	if opts != nil {
+		m.opts = *opts
+	}
+	if m.opts.PageSize < 0 {
+		return nil, table.ErrInvalidLimit
+	}
+	return m, nil
+}
+
+// Run copies the records of tables, ordering the tables so that referenced
+// tables come before the tables referencing them. Failed records are
+// reported in the Report and do not stop the run unless Options.StopOnError
+// is set; the returned error reports invalid tables and failed reads.
+func (m *Migrator) Run(ctx context.Context, tables ...Table) (*Report, error) {
+	order, err := m.prepare(tables)
+	if err != nil {
+		return nil, err
+	}
+
+	rep := &Report{}
+	for _, t := range order {
+		rep.Order = append(rep.Order, t.Name)
+		if err := m.runTable(ctx, t, rep); err != nil {
+			return rep, fmt.Errorf("migrate %s: %w", t.Name, err)
+		}
+	}
+	return rep, nil
+}
+
+// prepare validates tables and sorts them by dependency.
+func (m *Migrator) prepare(tables []Table) ([]Table, error) {
+	if len(tables) == 0 {
+		return nil, ErrNoTables
+	}
+
+	m.plan = map[string]Table{}
+	for _, t := range tables {
+		if t.Name == "" || strings.ContainsAny(t.Name, `/\\`) {
+			return nil, table.ErrInvalidTableName
+		}
+		if _, ok := m.plan[t.Name]; ok {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateTable, t.Name)
+		}
+		if len(t.Key) == 0 && !t.KeepSysID {
+			return nil, fmt.Errorf("%w: %s", ErrKeyRequired, t.Name)
+		}
+		for _, field := range t.Key {
+			if field == "sys_id" {
+				continue // always read
+			}
+			if src := sourceField(t, field); slices.Contains(t.Exclude, src) || len(t.Fields) > 0 && !slices.Contains(t.Fields, src) {
+				return nil, fmt.Errorf("%w: %s.%s", ErrKeyNotRead, t.Name, src)
+			}
+		}
+		m.plan[t.Name] = t
+	}
+	for _, t := range tables {
+		for field, ref := range t.References {
+			if ref.Table == "" || len(m.refKey(ref)) == 0 {
+				return nil, fmt.Errorf("%w: %s.%s", ErrInvalidRef, t.Name, field)
+			}
+		}
+	}
+
+	// Kahn's algorithm, keeping the given order among independent tables.
+	// References of a table to itself do not constrain the order.
+	done := map[string]bool{}
+	var order []Table
+	for len(order) < len(tables) {
+		progressed := false
+		for _, t := range tables {
+			if done[t.Name] || !m.depsDone(t, done) {
+				continue
+			}
+			done[t.Name] = true
+			order = append(order, t)
+			progressed = true
+			break
+		}
+		if !progressed {
+			var left []string
+			for _, t := range tables {
+				if !done[t.Name] {
+					left = append(left, t.Name)
+				}
+			}
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(left, ", "))
+		}
+	}
+	return order, nil
+}
+
+func (m *Migrator) depsDone(t Table, done map[string]bool) bool {
+	for _, ref := range t.References {
+		if _, planned := m.plan[ref.Table]; planned && ref.Table != t.Name && !done[ref.Table] {
+			return false
+		}
+	}
+	return true
+}
+
+// sourceField returns the source name of the target field, undoing t.Rename.
+func sourceField(t Table, field string) string {
+	for from, to := range t.Rename {
+		if to == field {
+			return from
+		}
+	}
+	return field
+}
+
+// refKey returns the key fields identifying records referenced by ref.
+func (m *Migrator) refKey(ref Reference) []string {
+	if len(ref.Key) > 0 {
+		return ref.Key
+	}
+	t, ok := m.plan[ref.Table]
+	switch {
+	case !ok:
+		return nil
+	case len(t.Key) > 0:
+		return t.Key
+	case t.KeepSysID:
+		return []string{"sys_id"}
+	}
+	return nil
+}
+
+// selfRef is a reference to a record of the same table that was not found
+// when the record was copied; its parent may come later in the table.
+type selfRef struct {
+	field    string // source field name
+	target   string // target field name
+	sourceID string // referenced source sys_id
+	current  string // value of the field on the target record
+}
+
+// pendingRecord is a copied record waiting for its self-references.
+type pendingRecord struct {
+	out  Outcome
+	refs []selfRef
+}
+
+func (m *Migrator) runTable(ctx context.Context, t Table, rep *Report) error {
+	src, err := table.NewMap(m.source, t.Name)
+	if err != nil {
+		return err
+	}
+	dst, err := table.NewMap(m.target, t.Name)
+	if err != nil {
+		return err
+	}
+
+	key := "migrate:" + t.Name
+	var cp table.Checkpoint
+	if m.opts.Store != nil {
+		stored, ok, err := m.opts.Store.Load(ctx, key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			cp = stored
+		}
+	}
+
+	opts := &table.SyncOptions{Query: t.Query, Fields: t.Fields, PageSize: m.opts.PageSize}
+	var pending []pendingRecord
+	for {
+		page, err := src.Changes(ctx, cp, opts)
+		if err != nil {
+			return err
+		}
+		for _, rec := range page.Result {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			out, refs := m.copyRecord(ctx, t, dst, rec)
+			if len(refs) > 0 {
+				pending = append(pending, pendingRecord{out: out, refs: refs})
+				continue
+			}
+			if err := m.report(rep, out); err != nil {
+				return err
+			}
+		}
+		if len(page.Result) > 0 {
+			cp = page.Next
+			// The checkpoint does not move past records still waiting for
+			// their self-references, so an interrupted run copies them again.
+			if m.opts.Store != nil && len(pending) == 0 {
+				if err := m.opts.Store.Save(ctx, key, cp); err != nil {
+					return err
+				}
+			}
+		}
+		if !page.More {
+			break
+		}
+	}
+
+	if len(pending) == 0 {
+		return nil
+	}
+	for _, p := range pending {
+		if err := m.report(rep, m.linkSelfRefs(ctx, t, dst, p)); err != nil {
+			return err
+		}
+	}
+	if m.opts.Store != nil {
+		return m.opts.Store.Save(ctx, key, cp)
+	}
+	return nil
+}
+
+// report records out, stopping the run if it failed and StopOnError is set.
+func (m *Migrator) report(rep *Report, out Outcome) error {
+	rep.Outcomes = append(rep.Outcomes, out)
+	if m.opts.OnRecord != nil {
+		m.opts.OnRecord(out)
+	}
+	if out.Err != nil && m.opts.StopOnError {
+		return table.RecordError{SysID: out.SourceSysID, Err: out.Err}
+	}
+	return nil
+}
+
+// copyRecord upserts one source record into the target. References to
+// records of the same table that are not on the target yet are left out and
+// returned, to be set by linkSelfRefs once the whole table is copied.
+func (m *Migrator) copyRecord(ctx context.Context, t Table, dst *table.Client[map[string]any], rec map[string]any) (Outcome, []selfRef) {
+	out := Outcome{Table: t.Name, SourceSysID: stringValue(rec["sys_id"])}
+	fail := func(err error) (Outcome, []selfRef) {
+		out.Action, out.Err = ActionFailed, err
+		return out, nil
+	}
+
+	body := map[string]any{}
+	for field, v := range rec {
+		if strings.HasPrefix(field, "sys_") && !(field == "sys_id" && t.KeepSysID) {
+			continue
+		}
+		if slices.Contains(t.Exclude, field) {
+			continue
+		}
+		body[field] = stringValue(v)
+	}
+
+	fields := make([]string, 0, len(t.References))
+	for field := range t.References {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var refs []selfRef
+	for _, field := range fields {
+		id, ok := body[field].(string)
+		if !ok || id == "" {
+			continue
+		}
+		ref := t.References[field]
+		resolved, found, err := m.resolve(ctx, ref, id)
+		if err != nil {
+			return fail(fmt.Errorf("resolve %s: %w", field, err))
+		}
+		if !found && ref.Table == t.Name {
+			target := field
+			if to, ok := t.Rename[field]; ok {
+				target = to
+			}
+			refs = append(refs, selfRef{field: field, target: target, sourceID: id})
+			delete(body, field)
+			continue
+		}
+		if !found {
+			out.Unresolved = append(out.Unresolved, field)
+		}
+		body[field] = resolved
+	}
+
+	for from, to := range t.Rename {
+		if v, ok := body[from]; ok {
+			delete(body, from)
+			body[to] = v
+		}
+	}
+
+	key := t.Key
+	if len(key) == 0 {
+		key = []string{"sys_id"}
+	}
+	read := slices.Collect(maps.Keys(body))
+	for _, r := range refs {
+		read = append(read, r.target)
+	}
+	existing, err := findByKey(ctx, dst, key, body, read)
+	if err != nil {
+		return fail(err)
+	}
+
+	if existing == nil {
+		created, err := dst.Create(ctx, body, &table.WriteOptions{Fields: []string{"sys_id"}})
+		if err != nil {
+			return fail(err)
+		}
+		out.Action, out.TargetSysID = ActionCreated, stringValue(created.Result["sys_id"])
+	} else {
+		out.TargetSysID = stringValue(existing["sys_id"])
+		for i, r := range refs {
+			refs[i].current = stringValue(existing[r.target])
+		}
+		patch := map[string]any{}
+		for field, v := range body {
+			if field != "sys_id" && stringValue(existing[field]) != v {
+				patch[field] = v
+			}
+		}
+		if len(patch) == 0 {
+			out.Action = ActionUnchanged
+		} else {
+			if _, err := dst.Update(ctx, out.TargetSysID, patch, &table.WriteOptions{Fields: []string{"sys_id"}}); err != nil {
+				return fail(err)
+			}
+			out.Action = ActionUpdated
+		}
+	}
+
+	m.mapped[t.Name+"/"+out.SourceSysID] = out.TargetSysID
+	return out, refs
+}
+
+// linkSelfRefs resolves the self-references of a record copied earlier in
+// the table and writes the ones that differ from the target.
+func (m *Migrator) linkSelfRefs(ctx context.Context, t Table, dst *table.Client[map[string]any], p pendingRecord) Outcome {
+	out := p.out
+	patch := map[string]any{}
+	for _, r := range p.refs {
+		resolved, found, err := m.resolve(ctx, t.References[r.field], r.sourceID)
+		if err != nil {
+			out.Action, out.Err = ActionFailed, fmt.Errorf("resolve %s: %w", r.field, err)
+			return out
+		}
+		if !found {
+			out.Unresolved = append(out.Unresolved, r.field)
+		}
+		if resolved != r.current {
+			patch[r.target] = resolved
+		}
+	}
+	sort.Strings(out.Unresolved)
+
+	if len(patch) > 0 {
+		if _, err := dst.Update(ctx, out.TargetSysID, patch, &table.WriteOptions{Fields: []string{"sys_id"}}); err != nil {
+			out.Action, out.Err = ActionFailed, err
+			return out
+		}
+		if out.Action == ActionUnchanged {
+			out.Action = ActionUpdated
+		}
+	}
+	return out
+}
+
+// resolve returns the target sys_id of the source record srcID referenced
+// through ref. found is false if the target has no such record.
+func (m *Migrator) resolve(ctx context.Context, ref Reference, srcID string) (id string, found bool, err error) {
+	if id, ok := m.mapped[ref.Table+"/"+srcID]; ok {
+		return id, true, nil
+	}
+
+	key := m.refKey(ref)
+	values := map[string]any{"sys_id": srcID}
+	if !slices.Equal(key, []string{"sys_id"}) {
+		src, err := table.NewMap(m.source, ref.Table)
+		if err != nil {
+			return "", false, err
+		}
+		rec, err := src.Get(ctx, srcID, &table.GetOptions{
+			Fields:               key,
+			DisplayValue:         table.DisplayValue(table.DisplayValueFalse),
+			ExcludeReferenceLink: table.Bool(true),
+		})
+		var apiErr *snow.APIError
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound {
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		values = rec.Result
+	}
+
+	dst, err := table.NewMap(m.target, ref.Table)
+	if err != nil {
+		return "", false, err
+	}
+	existing, err := findByKey(ctx, dst, key, values, nil)
+	if err != nil || existing == nil {
+		return "", false, err
+	}
+	id = stringValue(existing["sys_id"])
+	m.mapped[ref.Table+"/"+srcID] = id
+	return id, true, nil
+}
+
+// findByKey returns the target record whose key fields equal those of
+// values, with fields (all if empty), or nil if there is none.
+func findByKey(ctx context.Context, c *table.Client[map[string]any], key []string, values map[string]any, fields []string) (map[string]any, error) {
+	q := table.NewQueryBuilder()
+	for _, field := range key {
+		if v := stringValue(values[field]); v != "" {
+			q.Eq(field, v)
+		} else {
+			q.IsEmpty(field)
+		}
+	}
+	query, err := q.Build()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) > 0 && !slices.Contains(fields, "sys_id") {
+		fields = append(slices.Clone(fields), "sys_id")
+	}
+
+	resp, err := c.List(ctx, &table.ListOptions{
+		Query:                    query,
+		Fields:                   fields,
+		Limit:                    table.Int(2),
+		DisplayValue:             table.DisplayValue(table.DisplayValueFalse),
+		ExcludeReferenceLink:     table.Bool(true),
+		SuppressPaginationHeader: table.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	switch len(resp.Result) {
+	case 0:
+		return nil, nil
+	case 1:
+		return resp.Result[0], nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAmbiguousKey, query)
+}
+
+// stringValue returns the raw value of a Table API field, taking the value
+// of reference fields returned as {"link", "value"}.
+func stringValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any:
+		return stringValue(v["value"])
+	}
+	return fmt.Sprint(v)
+}
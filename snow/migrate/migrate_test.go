@@ -0,0 +1,284 @@
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// fakeInstance serves the Table API over in-memory tables. List queries made
+// of name=value and nameISEMPTY conditions are filtered; incremental sync
+// queries return every record.
+type fakeInstance struct {
+	mu     sync.Mutex
+	tables map[string]map[string]map[string]any
+	nextID int
+}
+
+func newFakeInstance(t *testing.T, tables map[string]map[string]map[string]any) (*fakeInstance, snow.Requester) {
+	t.Helper()
+
+	f := &fakeInstance{tables: tables}
+	srv := httptest.NewServer(f)
+	t.Cleanup(srv.Close)
+
+	sc, err := snow.NewClient(snow.WithInstanceURL(srv.URL), snow.WithBasicAuth("admin", "secret"))
+	if err != nil {
+		t.Fatalf("snow.NewClient() error = %v", err)
+	}
+	return f, sc
+}
+
+func (f *fakeInstance) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	name, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/api/now/table/"), "/")
+	if f.tables[name] == nil {
+		f.tables[name] = map[string]map[string]any{}
+	}
+	records := f.tables[name]
+
+	switch {
+	case r.Method == http.MethodGet && id == "":
+		query := r.URL.Query().Get("sysparm_query")
+		result := []map[string]any{}
+		for _, rec := range records {
+			if strings.HasPrefix(query, "sys_updated_on") || matches(rec, query) {
+				result = append(result, rec)
+			}
+		}
+		slices.SortFunc(result, func(a, b map[string]any) int { return strings.Compare(a["sys_id"].(string), b["sys_id"].(string)) })
+		json.NewEncoder(w).Encode(map[string]any{"result": result})
+	case r.Method == http.MethodGet:
+		rec, ok := records[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"No Record found"},"status":"failure"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": rec})
+	case r.Method == http.MethodPost:
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["sys_id"]; !ok {
+			f.nextID++
+			body["sys_id"] = fmt.Sprintf("new%d", f.nextID)
+		}
+		records[body["sys_id"].(string)] = body
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"result": body})
+	case r.Method == http.MethodPatch:
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		for k, v := range body {
+			records[id][k] = v
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": records[id]})
+	}
+}
+
+func matches(rec map[string]any, query string) bool {
+	for _, cond := range strings.Split(query, "^") {
+		if field, ok := strings.CutSuffix(cond, "ISEMPTY"); ok {
+			if v, _ := rec[field].(string); v != "" {
+				return false
+			}
+			continue
+		}
+		field, value, _ := strings.Cut(cond, "=")
+		if v, _ := rec[field].(string); v != value {
+			return false
+		}
+	}
+	return true
+}
+
+func ref(table, id string) map[string]any {
+	return map[string]any{"link": "https://prod.service-now.com/api/now/table/" + table + "/" + id, "value": id}
+}
+
+func TestRun(t *testing.T) {
+	const updated = "2024-01-01 00:00:00"
+	_, source := newFakeInstance(t, map[string]map[string]map[string]any{
+		"sys_user": {
+			"u1": {"sys_id": "u1", "user_name": "jdoe"},
+		},
+		"sys_user_group": {
+			"g1": {"sys_id": "g1", "sys_updated_on": updated, "sys_mod_count": "4", "name": "Network", "manager": ref("sys_user", "u1")},
+			"g2": {"sys_id": "g2", "sys_updated_on": updated, "sys_mod_count": "0", "name": "Database", "manager": ""},
+		},
+		"cmdb_ci_server": {
+			"c1": {"sys_id": "c1", "sys_updated_on": updated, "sys_mod_count": "1", "name": "srv1", "u_notes": "x",
+				"support_group": ref("sys_user_group", "g1"), "owned_by": ref("sys_user", "u9")},
+		},
+	})
+	target, dst := newFakeInstance(t, map[string]map[string]map[string]any{
+		"sys_user": {
+			"tu1": {"sys_id": "tu1", "user_name": "jdoe"},
+		},
+		"sys_user_group": {
+			"tg2": {"sys_id": "tg2", "name": "Database", "manager": ""},
+		},
+	})
+
+	tables := []Table{
+		{
+			Name:    "cmdb_ci_server",
+			Key:     []string{"name"},
+			Exclude: []string{"u_notes"},
+			References: map[string]Reference{
+				"support_group": {Table: "sys_user_group"},
+				"owned_by":      {Table: "sys_user", Key: []string{"user_name"}},
+			},
+		},
+		{
+			Name:       "sys_user_group",
+			Key:        []string{"name"},
+			References: map[string]Reference{"manager": {Table: "sys_user", Key: []string{"user_name"}}},
+		},
+	}
+
+	m, err := New(source, dst, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	rep, err := m.Run(context.Background(), tables...)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !slices.Equal(rep.Order, []string{"sys_user_group", "cmdb_ci_server"}) {
+		t.Fatalf("Order = %v", rep.Order)
+	}
+	if rep.Count(ActionCreated) != 2 || rep.Count(ActionUnchanged) != 1 || rep.Err() != nil {
+		t.Fatalf("Outcomes = %+v", rep.Outcomes)
+	}
+	if o := rep.Outcomes[2]; o.SourceSysID != "c1" || !slices.Equal(o.Unresolved, []string{"owned_by"}) {
+		t.Fatalf("Outcomes[2] = %+v", o)
+	}
+
+	group := target.tables["sys_user_group"][rep.Outcomes[0].TargetSysID]
+	if group["name"] != "Network" || group["manager"] != "tu1" || group["sys_mod_count"] != nil {
+		t.Errorf("target group = %v", group)
+	}
+	server := target.tables["cmdb_ci_server"][rep.Outcomes[2].TargetSysID]
+	if server["support_group"] != rep.Outcomes[0].TargetSysID || server["owned_by"] != "" || server["u_notes"] != nil || server["sys_id"] == "c1" {
+		t.Errorf("target server = %v", server)
+	}
+
+	// A second run finds everything by key.
+	m, _ = New(source, dst, nil)
+	rep, err = m.Run(context.Background(), tables...)
+	if err != nil {
+		t.Fatalf("Run() again error = %v", err)
+	}
+	if rep.Count(ActionUnchanged) != 3 {
+		t.Fatalf("Outcomes again = %+v", rep.Outcomes)
+	}
+}
+
+func TestRunSelfReferenceBeforeParent(t *testing.T) {
+	const updated = "2024-01-01 00:00:00"
+	_, source := newFakeInstance(t, map[string]map[string]map[string]any{
+		"sys_user_group": {
+			// The child sorts before its parent, so the parent is not on the
+			// target yet when the child is copied.
+			"a1": {"sys_id": "a1", "sys_updated_on": updated, "name": "Network EMEA", "parent": ref("sys_user_group", "b2")},
+			"b2": {"sys_id": "b2", "sys_updated_on": updated, "name": "Network", "parent": ""},
+		},
+	})
+	target, dst := newFakeInstance(t, map[string]map[string]map[string]any{
+		"sys_user_group": {
+			"t1": {"sys_id": "t1", "name": "Network EMEA", "parent": ""},
+		},
+	})
+	store, err := table.NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+	groups := Table{
+		Name:       "sys_user_group",
+		Key:        []string{"name"},
+		References: map[string]Reference{"parent": {Table: "sys_user_group"}},
+	}
+
+	m, err := New(source, dst, &Options{Store: store})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	rep, err := m.Run(context.Background(), groups)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if len(rep.Outcomes) != 2 || rep.Err() != nil {
+		t.Fatalf("Outcomes = %+v", rep.Outcomes)
+	}
+	parent, child := rep.Outcomes[0], rep.Outcomes[1]
+	if parent.SourceSysID != "b2" || parent.Action != ActionCreated {
+		t.Errorf("parent outcome = %+v", parent)
+	}
+	if child.SourceSysID != "a1" || child.Action != ActionUpdated || len(child.Unresolved) != 0 {
+		t.Errorf("child outcome = %+v", child)
+	}
+	if got := target.tables["sys_user_group"]["t1"]["parent"]; got != parent.TargetSysID {
+		t.Errorf("target child parent = %v, want %s", got, parent.TargetSysID)
+	}
+	if cp, ok, err := store.Load(context.Background(), "migrate:sys_user_group"); err != nil || !ok || cp.SysID != "b2" {
+		t.Errorf("stored checkpoint = %+v, %v, %v", cp, ok, err)
+	}
+
+	// A second run finds the parent by key on the first pass.
+	m, _ = New(source, dst, nil)
+	rep, err = m.Run(context.Background(), groups)
+	if err != nil {
+		t.Fatalf("Run() again error = %v", err)
+	}
+	if rep.Count(ActionUnchanged) != 2 {
+		t.Fatalf("Outcomes again = %+v", rep.Outcomes)
+	}
+}
+
+func TestRunInvalidTables(t *testing.T) {
+	_, sc := newFakeInstance(t, map[string]map[string]map[string]any{})
+	m, err := New(sc, sc, nil)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		tables []Table
+		want   error
+	}{
+		{"none", nil, ErrNoTables},
+		{"no key", []Table{{Name: "sys_user_group"}}, ErrKeyRequired},
+		{"key not in fields", []Table{{Name: "sys_user_group", Key: []string{"name"}, Fields: []string{"manager"}}}, ErrKeyNotRead},
+		{"renamed key not in fields", []Table{{Name: "sys_user_group", Key: []string{"u_name"}, Rename: map[string]string{"name": "u_name"},
+			Fields: []string{"u_name"}}}, ErrKeyNotRead},
+		{"key excluded", []Table{{Name: "sys_user_group", Key: []string{"name"}, Exclude: []string{"name"}}}, ErrKeyNotRead},
+		{"duplicate", []Table{{Name: "a", KeepSysID: true}, {Name: "a", KeepSysID: true}}, ErrDuplicateTable},
+		{"reference without key", []Table{{Name: "a", KeepSysID: true, References: map[string]Reference{"b": {Table: "b"}}}}, ErrInvalidRef},
+		{"cycle", []Table{
+			{Name: "a", KeepSysID: true, References: map[string]Reference{"b": {Table: "b"}}},
+			{Name: "b", KeepSysID: true, References: map[string]Reference{"a": {Table: "a"}}},
+		}, ErrDependencyCycle},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := m.Run(context.Background(), tt.tables...); !errors.Is(err, tt.want) {
+				t.Fatalf("Run() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package migrate
+
+import (
+	"errors"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+// Table describes how the records of one table are copied.
+type Table struct {
+	Name string
+	// Query filters the source records, e.g. "active=true". It cannot contain
+	// ^NQ or ORDERBY (see table.SyncOptions).
+	Query string
+	// Fields limits the fields read from the source; all fields if empty.
+	// It must include the Key fields, by source name when renamed.
+	Fields []string
+
+	// Key lists the fields, as written to the target, that identify a record
+	// on both instances, e.g. ["name"] for sys_user_group. Records are
+	// upserted: updated when the target has a record with the same key,
+	// created otherwise. Required unless KeepSysID is set, in which case
+	// records are matched by sys_id.
+	Key []string
+	// KeepSysID writes records with their source sys_id. Other sys_* fields
+	// are never written.
+	KeepSysID bool
+
+	// Exclude lists source fields that are not written.
+	Exclude []string
+	// Rename maps source field names to target field names.
+	Rename map[string]string
+	// References lists the reference fields to translate from source to
+	// target sys_ids, by source field name. Other reference fields are
+	// written as is.
+	// References to the table itself (e.g. sys_user_group.parent) may point
+	// to records later in the table; they are set at the end of the table.
+	References map[string]Reference
+}
+
+// Reference resolves a reference field on the target instance.
+type Reference struct {
+	Table string // referenced table, e.g. "sys_user_group"
+	// Key lists the fields of the referenced record that identify it on both
+	// instances; they must be plain (non-reference) fields with the same
+	// names on both. Defaults to the Key of Table when it is migrated in the
+	// same run, or sys_id if that keeps sys_ids.
+	Key []string
+}
+
+// Options configures a Migrator.
+type Options struct {
+	// Store records the progress per table (keyed "migrate:<table>"), so an
+	// interrupted run resumes after the last completed page and a later run
+	// only copies records changed since.
+	Store    table.CheckpointStore
+	PageSize int // Source records per request; defaults to 1000
+	// StopOnError stops the run at the first failed record. Its page is
+	// read again on the next run.
+	StopOnError bool
+	// OnRecord, if set, is called with every outcome as it happens. Records
+	// referencing a record of their own table that was not copied yet are
+	// reported at the end of the table, once the reference is set.
+	OnRecord func(Outcome)
+}
+
+// Action is what happened to a record.
+type Action string
+
+const (
+	ActionCreated   Action = "created"
+	ActionUpdated   Action = "updated"
+	ActionUnchanged Action = "unchanged"
+	ActionFailed    Action = "failed"
+)
+
+// Outcome is the result of copying one record.
+type Outcome struct {
+	Table       string
+	SourceSysID string
+	TargetSysID string
+	Action      Action
+	// Unresolved lists the reference fields that were written empty because
+	// the referenced record was not found on the target.
+	Unresolved []string
+	Err        error
+}
+
+// Report lists the outcome of every record copied by Run.
+type Report struct {
+	Order    []string // tables in the order they were copied
+	Outcomes []Outcome
+}
+
+// Count returns the number of records with action a.
+func (r *Report) Count(a Action) int {
+	n := 0
+	for _, o := range r.Outcomes {
+		if o.Action == a {
+			n++
+		}
+	}
+	return n
+}
+
+// Err joins the errors of failed records, or returns nil if there are none.
+func (r *Report) Err() error {
+	if r == nil {
+		return nil
+	}
+	var errs []error
+	for _, o := range r.Outcomes {
+		if o.Err != nil {
+			errs = append(errs, table.RecordError{SysID: o.Table + "/" + o.SourceSysID, Err: o.Err})
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,65 @@
+package snow
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrInvalidRateLimit = errors.New("rate limit requires requestsPerSecond > 0 and burst >= 1")
+
+// WithRateLimit limits the requests sent by the client to requestsPerSecond
+// on average, allowing bursts of up to burst requests. Requests over the
+// limit wait, or fail with the context's error if it ends first.
+func WithRateLimit(requestsPerSecond float64, burst int) Option {
+	return func(c *Client) error {
+		if requestsPerSecond <= 0 || burst < 1 {
+			return ErrInvalidRateLimit
+		}
+		c.limiter = &rateLimiter{
+			rate:   requestsPerSecond,
+			burst:  float64(burst),
+			tokens: float64(burst),
+			last:   time.Now(),
+		}
+		return nil
+	}
+}
+
+// rateLimiter is a token bucket. Tokens may go negative: each waiting
+// request reserves its token up front, so waiters are served in order.
+type rateLimiter struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	l.last = now
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
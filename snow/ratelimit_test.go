@@ -0,0 +1,36 @@
+package snow
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRateLimit(t *testing.T) {
+	if _, err := NewClient(WithRateLimit(0, 1)); !errors.Is(err, ErrInvalidRateLimit) {
+		t.Fatalf("NewClient() error = %v, want ErrInvalidRateLimit", err)
+	}
+
+	c, err := NewClient(WithInstanceURL("https://dev.service-now.com"), WithBasicAuth("admin", "secret"), WithRateLimit(1, 2))
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	ctx := context.Background()
+
+	start := time.Now()
+	for range 2 {
+		if err := c.limiter.wait(ctx); err != nil {
+			t.Fatalf("wait() error = %v", err)
+		}
+	}
+	if d := time.Since(start); d > 500*time.Millisecond {
+		t.Fatalf("burst of 2 took %v", d)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := c.limiter.wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("wait() over the limit error = %v, want DeadlineExceeded", err)
+	}
+}
@@ -0,0 +1,118 @@
+package table
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"path"
+	"strconv"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+const batchAPIPath = "/api/now/v1/batch"
+
+type batchRequest struct {
+	BatchRequestID string             `json:"batch_request_id"`
+	RestRequests   []batchRestRequest `json:"rest_requests"`
+}
+
+type batchRestRequest struct {
+	ID                     string        `json:"id"`
+	Method                 string        `json:"method"`
+	URL                    string        `json:"url"`
+	Headers                []batchHeader `json:"headers"`
+	Body                   string        `json:"body,omitempty"` // base64
+	ExcludeResponseHeaders bool          `json:"exclude_response_headers"`
+}
+
+type batchHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type batchResponse struct {
+	BatchRequestID   string `json:"batch_request_id"`
+	ServicedRequests []struct {
+		ID         string `json:"id"`
+		StatusCode int    `json:"status_code"`
+		Body       string `json:"body"` // base64
+	} `json:"serviced_requests"`
+	UnservicedRequests []string `json:"unserviced_requests"`
+}
+
+// batchPatch sends the same PATCH body to every record in ids as a single
+// Batch API request and returns one error per id. Records the instance did
+// not service get errNotWritten.
+func (c *Client[T]) batchPatch(ctx context.Context, ids []string, body []byte) []error {
+	errs := make([]error, len(ids))
+	fail := func(err error) []error {
+		for i := range errs {
+			errs[i] = err
+		}
+		return errs
+	}
+
+	base, err := c.basePath()
+	if err != nil {
+		return fail(err)
+	}
+
+	in := batchRequest{BatchRequestID: ids[0]}
+	encoded := base64.StdEncoding.EncodeToString(body)
+	for i, id := range ids {
+		in.RestRequests = append(in.RestRequests, batchRestRequest{
+			ID:     strconv.Itoa(i),
+			Method: http.MethodPatch,
+			URL:    path.Join(base, id) + "?sysparm_fields=sys_id",
+			Headers: []batchHeader{
+				{Name: "Content-Type", Value: "application/json"},
+				{Name: "Accept", Value: "application/json"},
+			},
+			Body:                   encoded,
+			ExcludeResponseHeaders: true,
+		})
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodPost, batchAPIPath, nil, in)
+	if err != nil {
+		return fail(err)
+	}
+	var out batchResponse
+	if err := c.r.Do(req, &out); err != nil {
+		return fail(err)
+	}
+
+	fail(errNotWritten)
+	for _, sr := range out.ServicedRequests {
+		i, err := strconv.Atoi(sr.ID)
+		if err != nil || i < 0 || i >= len(ids) {
+			continue
+		}
+		if sr.StatusCode >= 200 && sr.StatusCode < 300 {
+			errs[i] = nil
+			continue
+		}
+		errs[i] = batchAPIError(sr.StatusCode, sr.Body)
+	}
+	return errs
+}
+
+// batchAPIError builds the APIError of a failed request in a batch from its
+// base64 encoded body.
+func batchAPIError(status int, encoded string) error {
+	raw, _ := base64.StdEncoding.DecodeString(encoded)
+	apiErr := &snow.APIError{Status: status, Raw: raw}
+
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+			Detail  string `json:"detail"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(raw, &body) == nil {
+		apiErr.Message, apiErr.Detail = body.Error.Message, body.Error.Detail
+	}
+	return apiErr
+}
@@ -0,0 +1,334 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const (
+	defaultBulkConcurrency = 4
+	defaultBulkPageSize    = 1000
+)
+
+var (
+	ErrNilBulkQuery       = errors.New("bulk operation requires a query")
+	ErrEmptyBulkQuery     = errors.New("bulk operation query has no conditions")
+	ErrMaxRecordsRequired = errors.New("bulk operation requires MaxRecords > 0")
+	ErrTooManyRecords     = errors.New("query matches more records than MaxRecords")
+
+	errNotWritten = errors.New("record not written")
+)
+
+// BulkOptions configures DeleteWhere and UpdateWhere.
+type BulkOptions struct {
+	// MaxRecords is required: the operation is refused, before any write, when
+	// the query matches more records.
+	MaxRecords int
+	// DryRun only reports the records that would be written, in Skipped.
+	DryRun bool
+	// Concurrency is the number of parallel writes; defaults to 4.
+	Concurrency int
+	// StopOnError stops at the first failed write; records not written yet
+	// are reported in Skipped.
+	StopOnError bool
+	// PageSize is the number of sys_ids read per request; defaults to 1000.
+	PageSize int
+	// BatchSize, if > 0, sends UpdateWhere writes through the Batch API in
+	// requests of up to BatchSize records, each counting as one write for
+	// Concurrency.
+	BatchSize int
+}
+
+// BulkResult reports the outcome of a bulk operation per record, in sys_id order.
+type BulkResult struct {
+	Matched   int
+	Succeeded []string
+	Failed    []RecordError
+	Skipped   []string
+	DryRun    bool
+}
+
+// RecordError is a failed write of one record.
+type RecordError struct {
+	SysID string
+	Err   error
+}
+
+func (e RecordError) Error() string { return fmt.Sprintf("sys_id %s: %v", e.SysID, e.Err) }
+
+func (e RecordError) Unwrap() error { return e.Err }
+
+// Err joins the errors in Failed, or returns nil if there are none.
+func (r *BulkResult) Err() error {
+	if r == nil || len(r.Failed) == 0 {
+		return nil
+	}
+	errs := make([]error, len(r.Failed))
+	for i, f := range r.Failed {
+		errs[i] = f
+	}
+	return errors.Join(errs...)
+}
+
+// DeleteWhere deletes every record matching query. The matches are counted
+// first and the call fails with ErrTooManyRecords if there are more than
+// opts.MaxRecords; with opts.DryRun nothing is deleted and the matching
+// sys_ids are returned in Skipped. Failed deletes do not stop the others
+// unless opts.StopOnError is set; the returned error only reports problems
+// before the deletes start.
+func (c *Client[T]) DeleteWhere(ctx context.Context, query *QueryBuilder, opts *BulkOptions) (*BulkResult, error) {
+	ids, o, err := c.bulkMatches(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BulkResult{Matched: len(ids), DryRun: o.DryRun}
+	if o.DryRun {
+		res.Skipped = ids
+		return res, nil
+	}
+
+	runBulk(ctx, ids, 1, o, res, func(ctx context.Context, ids []string) []error {
+		return []error{c.Delete(ctx, ids[0], nil)}
+	})
+	return res, nil
+}
+
+// UpdateWhere applies patch, as Update would, to every record matching query.
+// The guards and the result are those of DeleteWhere. Matches are collected
+// by keyset iteration over sys_id before the first write, so patching fields
+// used in query does not skip or repeat records.
+func (c *Client[T]) UpdateWhere(ctx context.Context, query *QueryBuilder, patch any, opts *BulkOptions) (*BulkResult, error) {
+	if patch == nil {
+		return nil, ErrNilInput
+	}
+
+	ids, o, err := c.bulkMatches(ctx, query, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &BulkResult{Matched: len(ids), DryRun: o.DryRun}
+	if o.DryRun {
+		res.Skipped = ids
+		return res, nil
+	}
+
+	if o.BatchSize > 0 {
+		in, err := c.encode(patch)
+		if err != nil {
+			return nil, err
+		}
+		body, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		runBulk(ctx, ids, o.BatchSize, o, res, func(ctx context.Context, ids []string) []error {
+			undoIDs := make([]string, 0, len(ids))
+			for _, id := range ids {
+				undoID, err := c.captureUndo(ctx, UndoUpdate, id, in)
+				if err != nil {
+					// Nothing is written, so the entries captured so far are void.
+					for _, undoID := range undoIDs {
+						err = c.abortUndo(undoID, err, false)
+					}
+					errs := make([]error, len(ids))
+					for i := range errs {
+						errs[i] = err
+					}
+					return errs
+				}
+				undoIDs = append(undoIDs, undoID)
+			}
+			errs := c.batchPatch(ctx, ids, body)
+			for i, err := range errs {
+				if err != nil {
+					errs[i] = c.abortUndo(undoIDs[i], err, true)
+				}
+			}
+			return errs
+		})
+		return res, nil
+	}
+
+	writeOpts := &WriteOptions{Fields: []string{"sys_id"}}
+	runBulk(ctx, ids, 1, o, res, func(ctx context.Context, ids []string) []error {
+		_, err := c.Update(ctx, ids[0], patch, writeOpts)
+		return []error{err}
+	})
+	return res, nil
+}
+
+// bulkMatches validates a bulk operation and returns the sys_ids matching query.
+func (c *Client[T]) bulkMatches(ctx context.Context, query *QueryBuilder, opts *BulkOptions) ([]string, *BulkOptions, error) {
+	if c == nil || c.r == nil {
+		return nil, nil, ErrNilRequester
+	}
+	if query == nil {
+		return nil, nil, ErrNilBulkQuery
+	}
+	if opts == nil || opts.MaxRecords <= 0 {
+		return nil, nil, ErrMaxRecordsRequired
+	}
+
+	o := *opts
+	if o.Concurrency <= 0 {
+		o.Concurrency = defaultBulkConcurrency
+	}
+	if o.PageSize < 0 {
+		return nil, nil, ErrInvalidLimit
+	}
+	if o.PageSize == 0 {
+		o.PageSize = defaultBulkPageSize
+	}
+
+	filter, err := query.Build()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(query.terms) == 0 {
+		return nil, nil, ErrEmptyBulkQuery
+	}
+
+	// Count first, so an overly broad query fails before paging through it.
+	_, resp, err := c.listRaw(ctx, &ListOptions{Query: filter, Fields: []string{"sys_id"}, Limit: Int(1)})
+	if err != nil {
+		return nil, nil, err
+	}
+	if meta := parsePaginationHeaders(resp.Header); meta != nil && meta.TotalCount > o.MaxRecords {
+		return nil, nil, fmt.Errorf("%w: %d > %d", ErrTooManyRecords, meta.TotalCount, o.MaxRecords)
+	}
+
+	ids, err := c.matchingSysIDs(ctx, query, o.MaxRecords+1, o.PageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) > o.MaxRecords {
+		return nil, nil, fmt.Errorf("%w: more than %d", ErrTooManyRecords, o.MaxRecords)
+	}
+	return ids, &o, nil
+}
+
+// matchingSysIDs returns up to limit sys_ids matching query in sys_id order,
+// iterating by sys_id (keyset) rather than offset so that pages do not shift
+// when matching records change meanwhile.
+func (c *Client[T]) matchingSysIDs(ctx context.Context, query *QueryBuilder, limit, pageSize int) ([]string, error) {
+	base, err := query.form()
+	if err != nil {
+		return nil, err
+	}
+	order, err := NewQueryBuilder().OrderBy("sys_id").Build()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for len(ids) < limit {
+		page := base
+		if len(ids) > 0 {
+			after := queryForm{{{"sys_id>" + ids[len(ids)-1]}}}
+			if page, err = andForms([]queryForm{base, after}); err != nil {
+				return nil, err
+			}
+		}
+
+		want := min(pageSize, limit-len(ids))
+		raw, _, err := c.listRaw(ctx, &ListOptions{
+			Query:                    page.String() + "^" + order,
+			Fields:                   []string{"sys_id"},
+			Limit:                    Int(want),
+			SuppressPaginationHeader: Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range raw {
+			var meta syncMeta
+			if err := json.Unmarshal(rec, &meta); err != nil {
+				return nil, err
+			}
+			ids = append(ids, meta.SysID)
+		}
+		if len(raw) < want {
+			break
+		}
+	}
+	return ids, nil
+}
+
+// runBulk calls write for chunks of chunkSize ids with o.Concurrency workers
+// and records the outcome in res. write returns one error per id; errors
+// matching errNotWritten leave the record skipped.
+func runBulk(ctx context.Context, ids []string, chunkSize int, o *BulkOptions, res *BulkResult, write func(ctx context.Context, ids []string) []error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	const (
+		pending = iota
+		succeeded
+		failed
+	)
+	status := make([]int, len(ids))
+	errs := make([]error, len(ids))
+
+	var (
+		wg      sync.WaitGroup
+		stopped bool
+		mu      sync.Mutex
+		next    = make(chan int)
+	)
+
+	workers := min(o.Concurrency, (len(ids)+chunkSize-1)/chunkSize)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range next {
+				end := min(start+chunkSize, len(ids))
+				chunkErrs := write(ctx, ids[start:end])
+
+				mu.Lock()
+				for k, err := range chunkErrs {
+					i := start + k
+					switch {
+					case err == nil:
+						status[i] = succeeded
+					case errors.Is(err, errNotWritten), stopped && ctx.Err() != nil:
+					default:
+						status[i], errs[i] = failed, err
+						if o.StopOnError {
+							stopped = true
+							cancel()
+						}
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for start := 0; start < len(ids); start += chunkSize {
+		select {
+		case next <- start:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(next)
+	wg.Wait()
+
+	for i, id := range ids {
+		switch status[i] {
+		case succeeded:
+			res.Succeeded = append(res.Succeeded, id)
+		case failed:
+			res.Failed = append(res.Failed, RecordError{SysID: id, Err: errs[i]})
+		default:
+			res.Skipped = append(res.Skipped, id)
+		}
+	}
+}
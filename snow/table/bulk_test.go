@@ -0,0 +1,188 @@
+package table
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+// newBulkTestServer serves the records a, b and c of incident, paging by
+// keyset, and fails writes to b.
+func newBulkTestServer(t *testing.T, writes *[]string) *Client[map[string]any] {
+	t.Helper()
+
+	var mu sync.Mutex
+	return newTestClient[map[string]any](t, "incident", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			q := r.URL.Query()
+			if !strings.HasPrefix(q.Get("sysparm_query"), "active=false") {
+				t.Errorf("sysparm_query = %q", q.Get("sysparm_query"))
+			}
+			w.Header().Set("X-Total-Count", "3")
+			switch {
+			case q.Get("sysparm_limit") == "1":
+				w.Write([]byte(`{"result":[{"sys_id":"a"}]}`))
+			case strings.Contains(q.Get("sysparm_query"), "sys_id>b"):
+				w.Write([]byte(`{"result":[{"sys_id":"c"}]}`))
+			default:
+				w.Write([]byte(`{"result":[{"sys_id":"a"},{"sys_id":"b"}]}`))
+			}
+			return
+		}
+
+		if r.URL.Path == "/api/now/v1/batch" {
+			var in batchRequest
+			if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+				t.Errorf("decode batch request: %v", err)
+				return
+			}
+			out := map[string]any{"batch_request_id": in.BatchRequestID}
+			var serviced []map[string]any
+			for _, rr := range in.RestRequests {
+				body, _ := base64.StdEncoding.DecodeString(rr.Body)
+				if rr.Method != http.MethodPatch || string(body) != `{"assignment_group":"g2"}` {
+					t.Errorf("batch request = %+v, body %s", rr, body)
+				}
+				id := strings.TrimSuffix(strings.TrimPrefix(rr.URL, "/api/now/table/incident/"), "?sysparm_fields=sys_id")
+				mu.Lock()
+				*writes = append(*writes, "BATCH "+id)
+				mu.Unlock()
+				status, resp := 200, `{"result":{"sys_id":"`+id+`"}}`
+				if id == "b" {
+					status, resp = 403, `{"error":{"message":"Operation Failed"},"status":"failure"}`
+				}
+				if id == "c" {
+					out["unserviced_requests"] = []string{rr.ID}
+					continue
+				}
+				serviced = append(serviced, map[string]any{
+					"id": rr.ID, "status_code": status, "body": base64.StdEncoding.EncodeToString([]byte(resp)),
+				})
+			}
+			out["serviced_requests"] = serviced
+			json.NewEncoder(w).Encode(out)
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/api/now/table/incident/")
+		mu.Lock()
+		*writes = append(*writes, r.Method+" "+id)
+		mu.Unlock()
+		if id == "b" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"message":"Operation Failed"},"status":"failure"}`))
+			return
+		}
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.Write([]byte(`{"result":{"sys_id":"` + id + `"}}`))
+	})
+}
+
+func TestDeleteWhere(t *testing.T) {
+	var writes []string
+	c := newBulkTestServer(t, &writes)
+	ctx := context.Background()
+	query := func() *QueryBuilder { return NewQueryBuilder().Eq("active", false) }
+
+	if _, err := c.DeleteWhere(ctx, query(), &BulkOptions{MaxRecords: 2}); !errors.Is(err, ErrTooManyRecords) {
+		t.Fatalf("DeleteWhere() error = %v, want ErrTooManyRecords", err)
+	}
+
+	res, err := c.DeleteWhere(ctx, query(), &BulkOptions{MaxRecords: 10, DryRun: true, PageSize: 2})
+	if err != nil {
+		t.Fatalf("DeleteWhere() dry run error = %v", err)
+	}
+	if !res.DryRun || res.Matched != 3 || !slices.Equal(res.Skipped, []string{"a", "b", "c"}) || len(writes) != 0 {
+		t.Fatalf("DeleteWhere() dry run = %+v, writes %v", res, writes)
+	}
+
+	res, err = c.DeleteWhere(ctx, query(), &BulkOptions{MaxRecords: 10, PageSize: 2, Concurrency: 2})
+	if err != nil {
+		t.Fatalf("DeleteWhere() error = %v", err)
+	}
+	if !slices.Equal(res.Succeeded, []string{"a", "c"}) || len(res.Failed) != 1 || res.Failed[0].SysID != "b" {
+		t.Fatalf("DeleteWhere() = %+v", res)
+	}
+	var apiErr *snow.APIError
+	if !errors.As(res.Err(), &apiErr) || apiErr.Status != http.StatusForbidden {
+		t.Fatalf("Err() = %v, want 403 APIError", res.Err())
+	}
+}
+
+func TestDeleteWhereGuards(t *testing.T) {
+	var writes []string
+	c := newBulkTestServer(t, &writes)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		query *QueryBuilder
+		opts  *BulkOptions
+		want  error
+	}{
+		{"nil query", nil, &BulkOptions{MaxRecords: 1}, ErrNilBulkQuery},
+		{"empty query", NewQueryBuilder(), &BulkOptions{MaxRecords: 1}, ErrEmptyBulkQuery},
+		{"no max records", NewQueryBuilder().Eq("active", false), nil, ErrMaxRecordsRequired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := c.DeleteWhere(ctx, tt.query, tt.opts); !errors.Is(err, tt.want) {
+				t.Fatalf("DeleteWhere() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if len(writes) != 0 {
+		t.Fatalf("writes = %v, want none", writes)
+	}
+}
+
+func TestUpdateWhere(t *testing.T) {
+	var writes []string
+	c := newBulkTestServer(t, &writes)
+	ctx := context.Background()
+	patch := map[string]string{"assignment_group": "g2"}
+
+	res, err := c.UpdateWhere(ctx, NewQueryBuilder().Eq("active", false), patch, &BulkOptions{
+		MaxRecords:  10,
+		PageSize:    2,
+		Concurrency: 1,
+		StopOnError: true,
+	})
+	if err != nil {
+		t.Fatalf("UpdateWhere() error = %v", err)
+	}
+	if !slices.Equal(res.Succeeded, []string{"a"}) || len(res.Failed) != 1 || !slices.Equal(res.Skipped, []string{"c"}) {
+		t.Fatalf("UpdateWhere() = %+v", res)
+	}
+	if !slices.Equal(writes, []string{"PATCH a", "PATCH b"}) {
+		t.Fatalf("writes = %v", writes)
+	}
+
+	writes = nil
+	res, err = c.UpdateWhere(ctx, NewQueryBuilder().Eq("active", false), patch, &BulkOptions{
+		MaxRecords: 10,
+		PageSize:   2,
+		BatchSize:  10,
+	})
+	if err != nil {
+		t.Fatalf("UpdateWhere() batch error = %v", err)
+	}
+	if !slices.Equal(res.Succeeded, []string{"a"}) || len(res.Failed) != 1 || res.Failed[0].SysID != "b" || !slices.Equal(res.Skipped, []string{"c"}) {
+		t.Fatalf("UpdateWhere() batch = %+v", res)
+	}
+	var apiErr *snow.APIError
+	if !errors.As(res.Failed[0].Err, &apiErr) || apiErr.Status != http.StatusForbidden || apiErr.Message != "Operation Failed" {
+		t.Fatalf("Failed[0].Err = %v", res.Failed[0].Err)
+	}
+}
@@ -0,0 +1,116 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrEmptyCheckpointPath = errors.New("checkpoint file path is empty")
+
+// Checkpoint is a position in the change stream of a table: the last record
+// seen, by sys_updated_on with sys_id breaking ties between records updated in
+// the same second. The zero Checkpoint is the start of the table.
+type Checkpoint struct {
+	UpdatedOn time.Time `json:"updated_on"`
+	SysID     string    `json:"sys_id"`
+}
+
+// IsZero reports whether cp is the start of the table.
+func (cp Checkpoint) IsZero() bool {
+	return cp.UpdatedOn.IsZero() && cp.SysID == ""
+}
+
+// CheckpointStore persists checkpoints by key, typically one key per table
+// and consumer.
+type CheckpointStore interface {
+	// Load returns the checkpoint stored under key; ok is false if there is none.
+	Load(ctx context.Context, key string) (cp Checkpoint, ok bool, err error)
+	Save(ctx context.Context, key string, cp Checkpoint) error
+}
+
+// FileCheckpointStore is a CheckpointStore keeping all checkpoints in one JSON
+// file. Saves replace the file atomically. It is safe for concurrent use
+// within a process, but not across processes.
+type FileCheckpointStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCheckpointStore returns a store backed by the file at path, which is
+// created on the first Save.
+func NewFileCheckpointStore(path string) (*FileCheckpointStore, error) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return nil, ErrEmptyCheckpointPath
+	}
+	return &FileCheckpointStore{path: path}, nil
+}
+
+func (s *FileCheckpointStore) Load(_ context.Context, key string) (Checkpoint, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return Checkpoint{}, false, err
+	}
+	cp, ok := all[key]
+	return cp, ok, nil
+}
+
+func (s *FileCheckpointStore) Save(_ context.Context, key string, cp Checkpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[key] = cp
+
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileCheckpointStore) read() (map[string]Checkpoint, error) {
+	all := map[string]Checkpoint{}
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return all, nil
+	}
+
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
@@ -0,0 +1,224 @@
+package table
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+var (
+	jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// UnmarshalDotWalked decodes a record whose dot-walked fields come back as
+// flat keys ("caller_id.name") into v, nesting them first:
+//
+//	{"caller_id": "6816f79c...", "caller_id.name": "Abel Tuter"}
+//
+// decodes like
+//
+//	{"caller_id": {"value": "6816f79c...", "name": "Abel Tuter"}}
+//
+// so a struct field tagged `json:"caller_id"` can hold the dot-walked values.
+// A plain value of the reference field moves to the "value" member; an object
+// value (e.g. {"link", "value"}) is merged.
+func UnmarshalDotWalked(data []byte, v any) error {
+	nested, err := nestDotWalked(data, reflect.TypeOf(v))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(nested, v)
+}
+
+// MarshalDotWalked is the inverse of UnmarshalDotWalked: fields of v that are
+// dot-walk containers (see WithDotWalking) are flattened back into dotted keys,
+// and their "value" member becomes the reference field itself.
+func MarshalDotWalked(v any) ([]byte, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || !isJSONObject(raw) {
+		return raw, nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	out := map[string]json.RawMessage{}
+	if err := flattenDotWalked(t, obj, "", out); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(out)
+}
+
+// nestDotWalked nests the dotted keys of data. Plain values of fields that
+// are dot-walk containers in t are wrapped as {"value": ...} even without
+// dotted keys, so they decode the same way.
+func nestDotWalked(data []byte, t reflect.Type) ([]byte, error) {
+	if !isJSONObject(data) {
+		return data, nil
+	}
+
+	var flat map[string]json.RawMessage
+	if err := json.Unmarshal(data, &flat); err != nil {
+		return nil, err
+	}
+
+	root := map[string]any{}
+	for key, value := range flat {
+		if err := setDotWalked(root, strings.Split(key, "."), value); err != nil {
+			return nil, err
+		}
+	}
+	if err := wrapContainers(root, t); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(root)
+}
+
+func wrapContainers(node map[string]any, t reflect.Type) error {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	for key, ct := range dotWalkContainers(t) {
+		switch v := node[key].(type) {
+		case json.RawMessage:
+			if isJSONObject(v) || string(bytes.TrimSpace(v)) == "null" {
+				continue
+			}
+			child := map[string]any{}
+			if err := mergeDotWalked(child, v); err != nil {
+				return err
+			}
+			node[key] = child
+		case map[string]any:
+			if err := wrapContainers(v, ct); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setDotWalked stores value under path, turning plain values that are in the
+// way into {"value": ...} objects.
+func setDotWalked(node map[string]any, path []string, value json.RawMessage) error {
+	key := path[0]
+	if len(path) == 1 {
+		if child, ok := node[key].(map[string]any); ok {
+			return mergeDotWalked(child, value)
+		}
+		node[key] = value
+		return nil
+	}
+
+	child, ok := node[key].(map[string]any)
+	if !ok {
+		child = map[string]any{}
+		if existing, ok := node[key].(json.RawMessage); ok {
+			if err := mergeDotWalked(child, existing); err != nil {
+				return err
+			}
+		}
+		node[key] = child
+	}
+
+	return setDotWalked(child, path[1:], value)
+}
+
+func mergeDotWalked(node map[string]any, value json.RawMessage) error {
+	if !isJSONObject(value) {
+		node["value"] = value
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(value, &obj); err != nil {
+		return err
+	}
+	for k, v := range obj {
+		if _, ok := node[k]; !ok {
+			node[k] = v
+		}
+	}
+	return nil
+}
+
+func flattenDotWalked(t reflect.Type, obj map[string]json.RawMessage, prefix string, out map[string]json.RawMessage) error {
+	containers := dotWalkContainers(t)
+	for key, value := range obj {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+			if key == "value" {
+				name = prefix
+			}
+		}
+
+		ct, ok := containers[key]
+		if !ok || !isJSONObject(value) {
+			out[name] = value
+			continue
+		}
+
+		var child map[string]json.RawMessage
+		if err := json.Unmarshal(value, &child); err != nil {
+			return err
+		}
+		if err := flattenDotWalked(ct, child, name, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dotWalkContainers maps json names of the struct-typed fields of t to their types.
+func dotWalkContainers(t reflect.Type) map[string]reflect.Type {
+	out := map[string]reflect.Type{}
+	for name, ft := range JSONFieldTypes(t) {
+		if ct, ok := dotWalkContainer(ft); ok {
+			out[name] = ct
+		}
+	}
+	return out
+}
+
+// dotWalkContainer reports whether t is a plain struct that can hold
+// dot-walked fields, rather than a value with its own JSON encoding.
+func dotWalkContainer(t reflect.Type) (reflect.Type, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	pt := reflect.PointerTo(t)
+	if pt.Implements(jsonUnmarshalerType) || pt.Implements(textUnmarshalerType) {
+		return nil, false
+	}
+
+	return t, true
+}
+
+func isJSONObject(data []byte) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '{'
+}
@@ -0,0 +1,94 @@
+package table
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type dotWalkTestIncident struct {
+	Number string `json:"number"`
+	Caller struct {
+		Value string `json:"value"`
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"caller_id"`
+}
+
+func TestUnmarshalDotWalked(t *testing.T) {
+	raw := []byte(`{"number":"INC0010001","caller_id":"6816f79c","caller_id.name":"Abel Tuter","caller_id.email":"abel.tuter@example.com"}`)
+
+	var got dotWalkTestIncident
+	if err := UnmarshalDotWalked(raw, &got); err != nil {
+		t.Fatalf("UnmarshalDotWalked() error = %v", err)
+	}
+
+	if got.Number != "INC0010001" || got.Caller.Value != "6816f79c" ||
+		got.Caller.Name != "Abel Tuter" || got.Caller.Email != "abel.tuter@example.com" {
+		t.Fatalf("UnmarshalDotWalked() = %+v", got)
+	}
+}
+
+func TestUnmarshalDotWalkedMergesReferenceObject(t *testing.T) {
+	raw := []byte(`{"caller_id.name":"Abel Tuter","caller_id":{"link":"https://x/api","value":"6816f79c"}}`)
+
+	var got dotWalkTestIncident
+	if err := UnmarshalDotWalked(raw, &got); err != nil {
+		t.Fatalf("UnmarshalDotWalked() error = %v", err)
+	}
+	if got.Caller.Value != "6816f79c" || got.Caller.Name != "Abel Tuter" {
+		t.Fatalf("UnmarshalDotWalked() = %+v", got)
+	}
+}
+
+func TestMarshalDotWalked(t *testing.T) {
+	var in dotWalkTestIncident
+	in.Number = "INC0010001"
+	in.Caller.Value = "6816f79c"
+	in.Caller.Name = "Abel Tuter"
+
+	raw, err := MarshalDotWalked(in)
+	if err != nil {
+		t.Fatalf("MarshalDotWalked() error = %v", err)
+	}
+
+	var got map[string]string
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	want := map[string]string{
+		"number":          "INC0010001",
+		"caller_id":       "6816f79c",
+		"caller_id.name":  "Abel Tuter",
+		"caller_id.email": "",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("MarshalDotWalked() = %v, want %v", got, want)
+	}
+}
+
+func TestDotWalkedStructFields(t *testing.T) {
+	got := DotWalkedStructFields[dotWalkTestIncident]()
+	want := []string{"number", "caller_id", "caller_id.name", "caller_id.email"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("DotWalkedStructFields() = %v, want %v", got, want)
+	}
+}
+
+func TestUnmarshalDotWalkedPlainReference(t *testing.T) {
+	// Without dot-walked keys, a plain reference value still fills the
+	// container's value member.
+	raw := []byte(`{"number":"INC0010001","caller_id":"6816f79c"}`)
+
+	var got dotWalkTestIncident
+	if err := UnmarshalDotWalked(raw, &got); err != nil {
+		t.Fatalf("UnmarshalDotWalked() error = %v", err)
+	}
+	if got.Number != "INC0010001" || got.Caller.Value != "6816f79c" {
+		t.Fatalf("UnmarshalDotWalked() = %+v", got)
+	}
+
+	if err := UnmarshalDotWalked([]byte(`{"caller_id":null}`), &got); err != nil {
+		t.Fatalf("UnmarshalDotWalked(null) error = %v", err)
+	}
+}
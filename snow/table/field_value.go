@@ -0,0 +1,73 @@
+package table
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// FieldValue is a field value in the shape returned with
+// sysparm_display_value=all, and always by some APIs such as Change
+// Management:
+//
+//	{"value": "2", "display_value": "In Progress"}
+//
+// Plain JSON values (strings, numbers, booleans) decode into Value, so a
+// FieldValue can be used regardless of the display value setting.
+type FieldValue struct {
+	Value        string `json:"value"`
+	DisplayValue string `json:"display_value"`
+	Link         string `json:"link,omitempty"`
+}
+
+func (f FieldValue) String() string { return f.Value }
+
+func (f *FieldValue) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case len(data) == 0 || bytes.Equal(data, []byte("null")):
+		*f = FieldValue{}
+		return nil
+	case data[0] == '{':
+		var obj struct {
+			Value        any    `json:"value"`
+			DisplayValue any    `json:"display_value"`
+			Link         string `json:"link"`
+		}
+		// Keep numbers as written; float64 would turn 1000000 into "1e+06".
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&obj); err != nil {
+			return err
+		}
+		*f = FieldValue{
+			Value:        scalarString(obj.Value),
+			DisplayValue: scalarString(obj.DisplayValue),
+			Link:         obj.Link,
+		}
+		return nil
+	case data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*f = FieldValue{Value: s}
+		return nil
+	default:
+		*f = FieldValue{Value: string(data)}
+		return nil
+	}
+}
+
+func scalarString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
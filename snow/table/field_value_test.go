@@ -0,0 +1,49 @@
+package table
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestFieldValueUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want FieldValue
+	}{
+		{"display value object", `{"value":"2","display_value":"In Progress"}`, FieldValue{Value: "2", DisplayValue: "In Progress"}},
+		{"reference with link", `{"value":"6816f79c","display_value":"Abel Tuter","link":"https://x/api/now/table/sys_user/6816f79c"}`,
+			FieldValue{Value: "6816f79c", DisplayValue: "Abel Tuter", Link: "https://x/api/now/table/sys_user/6816f79c"}},
+		{"non-string members", `{"value":3,"display_value":true}`, FieldValue{Value: "3", DisplayValue: "true"}},
+		{"large number", `{"value":1000000,"display_value":"1,000,000"}`, FieldValue{Value: "1000000", DisplayValue: "1,000,000"}},
+		{"precise number", `{"value":12345678901234567890.5}`, FieldValue{Value: "12345678901234567890.5"}},
+		{"null members", `{"value":null,"display_value":null}`, FieldValue{}},
+		{"string", `"INC0010001"`, FieldValue{Value: "INC0010001"}},
+		{"number", `42.5`, FieldValue{Value: "42.5"}},
+		{"bool", `false`, FieldValue{Value: "false"}},
+		{"null", `null`, FieldValue{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				F FieldValue `json:"f"`
+			}
+			got.F = FieldValue{Value: "stale", DisplayValue: "stale"}
+			if err := json.Unmarshal([]byte(`{"f":`+tt.raw+`}`), &got); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got.F != tt.want {
+				t.Fatalf("Unmarshal() = %+v, want %+v", got.F, tt.want)
+			}
+			if got.F.String() != tt.want.Value {
+				t.Fatalf("String() = %q, want %q", got.F.String(), tt.want.Value)
+			}
+		})
+	}
+
+	var f FieldValue
+	if err := json.Unmarshal([]byte(`{"value":`), &f); err == nil {
+		t.Fatal("Unmarshal() truncated object error = nil")
+	}
+}
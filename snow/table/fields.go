@@ -0,0 +1,255 @@
+package table
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var structFieldsCache sync.Map // map[structFieldsKey][]string
+
+type structFieldsKey struct {
+	t       reflect.Type
+	dotWalk bool
+}
+
+// StructFields returns the ServiceNow field names of T, derived from its json
+// tags. Dot-walked tags such as `json:"assigned_to.email"` are returned as is.
+// Fields without a json tag, or tagged "-", are skipped. It returns nil when T
+// is not a struct.
+func StructFields[T any]() []string {
+	return structFields(reflect.TypeFor[T](), false)
+}
+
+// DotWalkedStructFields is like StructFields, but expands struct-typed fields
+// into dot-walked names the way UnmarshalDotWalked nests them: a field tagged
+// `json:"caller_id"` holding Name and Value members yields "caller_id" and
+// "caller_id.name".
+func DotWalkedStructFields[T any]() []string {
+	return structFields(reflect.TypeFor[T](), true)
+}
+
+// FieldOf returns the ServiceNow field name of the struct field selected by
+// sel, so queries can be written against struct fields and checked by the
+// compiler:
+//
+//	number := table.FieldOf(func(i *Incident) any { return &i.Number })
+//
+// sel must return a pointer to a json-tagged field of T (nested struct values
+// give dot-walked names, also through pointer fields). Otherwise FieldOf
+// returns "", which QueryBuilder rejects with ErrEmptyQueryField.
+func FieldOf[T any](sel func(*T) any) (name string) {
+	if sel == nil {
+		return ""
+	}
+
+	var zero T
+	root := reflect.ValueOf(&zero).Elem()
+	if root.Kind() != reflect.Struct {
+		return ""
+	}
+	allocStructPointers(root, map[reflect.Type]bool{root.Type(): true})
+
+	defer func() {
+		if recover() != nil {
+			name = ""
+		}
+	}()
+	target := reflect.ValueOf(sel(&zero))
+	if !target.IsValid() || target.Kind() != reflect.Pointer || target.IsNil() {
+		return ""
+	}
+
+	return fieldPath(root, target.Pointer(), target.Type().Elem())
+}
+
+// allocStructPointers sets the nil pointer-to-struct fields of v to new
+// values, so selectors can go through them. Types already on the path are
+// left nil to stop on recursive types.
+func allocStructPointers(v reflect.Value, path map[reflect.Type]bool) {
+	for i := 0; i < v.NumField(); i++ {
+		fv := v.Field(i)
+		ft := fv.Type()
+		if ft.Kind() == reflect.Pointer {
+			if !fv.CanSet() || ft.Elem().Kind() != reflect.Struct || path[ft.Elem()] {
+				continue
+			}
+			fv.Set(reflect.New(ft.Elem()))
+			fv, ft = fv.Elem(), ft.Elem()
+		} else if ft.Kind() != reflect.Struct || path[ft] {
+			continue
+		}
+
+		path[ft] = true
+		allocStructPointers(fv, path)
+		delete(path, ft)
+	}
+}
+
+func fieldPath(v reflect.Value, addr uintptr, typ reflect.Type) string {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Pointer && fv.Addr().Pointer() != addr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+
+		name, ok := jsonFieldName(sf)
+		if !ok {
+			if embeddedFields(sf) && fv.Kind() == reflect.Struct {
+				if path := fieldPath(fv, addr, typ); path != "" {
+					return path
+				}
+			}
+			continue
+		}
+
+		if fv.Addr().Pointer() == addr && sf.Type == typ {
+			return name
+		}
+		if fv.Kind() == reflect.Struct {
+			if path := fieldPath(fv, addr, typ); path != "" {
+				return name + "." + path
+			}
+		}
+	}
+
+	return ""
+}
+
+func structFields(t reflect.Type, dotWalk bool) []string {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	key := structFieldsKey{t: t, dotWalk: dotWalk}
+	if cached, ok := structFieldsCache.Load(key); ok {
+		return slices.Clone(cached.([]string))
+	}
+
+	var fields []string
+	seen := map[string]bool{}
+	collectStructFields(t, "", dotWalk, seen, &fields)
+
+	// Callers own the returned slice; keep a copy they cannot change.
+	structFieldsCache.Store(key, slices.Clone(fields))
+	return fields
+}
+
+func collectStructFields(t reflect.Type, prefix string, dotWalk bool, seen map[string]bool, out *[]string) {
+	walkJSONFields(t, 0, func(name string, sf reflect.StructField, _ int) {
+		if prefix != "" {
+			if name == "value" {
+				name = prefix
+			} else {
+				name = prefix + "." + name
+			}
+		}
+
+		if ct, ok := dotWalkContainer(sf.Type); ok && dotWalk {
+			collectStructFields(ct, name, dotWalk, seen, out)
+			return
+		}
+
+		if !seen[name] {
+			seen[name] = true
+			*out = append(*out, name)
+		}
+	})
+}
+
+// JSONFieldTypes maps the json names of the fields of the struct type t to
+// their types, reading t the way StructFields does: fields need a json tag,
+// and untagged embedded structs, also through pointers, contribute their
+// fields. A field declared at a shallower depth wins over an embedded one of
+// the same name. It returns nil when t is not a struct.
+func JSONFieldTypes(t reflect.Type) map[string]reflect.Type {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	out := map[string]reflect.Type{}
+	depths := map[string]int{}
+	walkJSONFields(t, 0, func(name string, sf reflect.StructField, depth int) {
+		if d, ok := depths[name]; ok && d <= depth {
+			return
+		}
+		out[name], depths[name] = sf.Type, depth
+	})
+	return out
+}
+
+// walkJSONFields calls fn, in declaration order, for each json-tagged field
+// of the struct type t, descending into untagged embedded structs. Fields
+// tagged "-", embedded or not, are skipped.
+func walkJSONFields(t reflect.Type, depth int, fn func(name string, sf reflect.StructField, depth int)) {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+
+		name, ok := jsonFieldName(sf)
+		if !ok {
+			if embeddedFields(sf) {
+				ft := sf.Type
+				for ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walkJSONFields(ft, depth+1, fn)
+				}
+			}
+			continue
+		}
+		fn(name, sf, depth)
+	}
+}
+
+// jsonFieldName returns the name from the json tag of an exported field.
+func jsonFieldName(sf reflect.StructField) (string, bool) {
+	if !sf.IsExported() {
+		return "", false
+	}
+
+	tag, ok := sf.Tag.Lookup("json")
+	if !ok {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "" || name == "-" {
+		return "", false
+	}
+
+	return name, true
+}
+
+// embeddedFields reports whether sf is an embedded struct field whose fields
+// are promoted, i.e. one without a json name, as encoding/json has it.
+func embeddedFields(sf reflect.StructField) bool {
+	name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+	return sf.Anonymous && name == ""
+}
+
+// knownField reports whether field, or one of its dot-walk prefixes, is in fields.
+func knownField(fields map[string]bool, field string) bool {
+	for {
+		if fields[field] {
+			return true
+		}
+		i := strings.LastIndex(field, ".")
+		if i < 0 {
+			return false
+		}
+		field = field[:i]
+	}
+}
@@ -0,0 +1,149 @@
+package table
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type fieldsTestIncident struct {
+	SysID         string `json:"sys_id"`
+	Number        string `json:"number"`
+	AssignedEmail string `json:"assigned_to.email,omitempty"`
+	Caller        struct {
+		Name string `json:"name"`
+	} `json:"caller_id"`
+	Internal string `json:"-"`
+	Untagged string
+}
+
+func TestStructFields(t *testing.T) {
+	got := StructFields[fieldsTestIncident]()
+	want := []string{"sys_id", "number", "assigned_to.email", "caller_id"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("StructFields() = %v, want %v", got, want)
+	}
+
+	got[0] = "changed"
+	if again := StructFields[fieldsTestIncident](); again[0] != "sys_id" {
+		t.Fatalf("StructFields() after changing a result = %v", again)
+	}
+
+	if got := StructFields[map[string]any](); got != nil {
+		t.Fatalf("StructFields() for map = %v, want nil", got)
+	}
+}
+
+type fieldsTestBase struct {
+	Number string `json:"number"`
+	State  string `json:"state"`
+}
+
+type fieldsTestHidden struct {
+	Secret string `json:"secret"`
+}
+
+type fieldsTestEmbedding struct {
+	*fieldsTestBase
+	fieldsTestHidden `json:"-"`
+	State            int `json:"state"`
+}
+
+func TestJSONFieldTypes(t *testing.T) {
+	got := JSONFieldTypes(reflect.TypeFor[*fieldsTestEmbedding]())
+	want := map[string]reflect.Type{
+		"number": reflect.TypeFor[string](),
+		"state":  reflect.TypeFor[int](),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("JSONFieldTypes() = %v, want %v", got, want)
+	}
+	if got := StructFields[fieldsTestEmbedding](); !reflect.DeepEqual(got, []string{"number", "state"}) {
+		t.Fatalf("StructFields() = %v", got)
+	}
+
+	if got := JSONFieldTypes(reflect.TypeFor[string]()); got != nil {
+		t.Fatalf("JSONFieldTypes() for string = %v, want nil", got)
+	}
+}
+
+func TestFieldOf(t *testing.T) {
+	tests := []struct {
+		name string
+		sel  func(*fieldsTestIncident) any
+		want string
+	}{
+		{"first field", func(i *fieldsTestIncident) any { return &i.SysID }, "sys_id"},
+		{"dot-walked tag", func(i *fieldsTestIncident) any { return &i.AssignedEmail }, "assigned_to.email"},
+		{"nested struct", func(i *fieldsTestIncident) any { return &i.Caller }, "caller_id"},
+		{"nested field", func(i *fieldsTestIncident) any { return &i.Caller.Name }, "caller_id.name"},
+		{"untagged", func(i *fieldsTestIncident) any { return &i.Untagged }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldOf(tt.sel); got != tt.want {
+				t.Fatalf("FieldOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type fieldsTestRef struct {
+	Name  string             `json:"name"`
+	Next  *fieldsTestRef     `json:"next"`
+	Group *fieldsTestGroupID `json:"group"`
+}
+
+type fieldsTestGroupID struct {
+	Name string `json:"name"`
+}
+
+type fieldsTestTask struct {
+	Number string         `json:"number"`
+	Caller *fieldsTestRef `json:"caller_id"`
+	Tags   []string       `json:"tags"`
+}
+
+func TestFieldOfThroughPointers(t *testing.T) {
+	tests := []struct {
+		name string
+		sel  func(*fieldsTestTask) any
+		want string
+	}{
+		{"pointer field", func(i *fieldsTestTask) any { return &i.Caller }, "caller_id"},
+		{"through pointer", func(i *fieldsTestTask) any { return &i.Caller.Name }, "caller_id.name"},
+		{"two pointers deep", func(i *fieldsTestTask) any { return &i.Caller.Group.Name }, "caller_id.group.name"},
+		{"recursive type", func(i *fieldsTestTask) any { return &i.Caller.Next.Name }, ""},
+		{"out of range", func(i *fieldsTestTask) any { return &i.Tags[0] }, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FieldOf(tt.sel); got != tt.want {
+				t.Fatalf("FieldOf() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderForRejectsUnknownField(t *testing.T) {
+	query, err := NewQueryBuilderFor[fieldsTestIncident]().
+		Eq(FieldOf(func(i *fieldsTestIncident) any { return &i.Number }), "INC0010001").
+		IsNotEmpty("caller_id.email").
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	const want = "number=INC0010001^caller_id.emailISNOTEMPTY"
+	if query != want {
+		t.Fatalf("Build() = %q, want %q", query, want)
+	}
+
+	_, err = NewQueryBuilderFor[fieldsTestIncident]().
+		Eq("numbr", "INC0010001").
+		Build()
+	if !errors.Is(err, ErrUnknownQueryField) {
+		t.Fatalf("Build() error = %v, want %v", err, ErrUnknownQueryField)
+	}
+}
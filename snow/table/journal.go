@@ -0,0 +1,211 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	JournalWorkNotes = "work_notes"
+	JournalComments  = "comments"
+
+	journalPageSize = 1000
+)
+
+var ErrEmptyJournalValue = errors.New("journal value cannot be empty")
+
+// JournalEntry is a work note, comment or other journal field entry.
+type JournalEntry struct {
+	SysID     string // sys_journal_field sys_id; empty when read via display value
+	Element   string // journal field, e.g. work_notes
+	Value     string
+	Author    string // user name, or display name when read via display value
+	CreatedOn time.Time
+}
+
+// JournalOptions configures Journal.
+type JournalOptions struct {
+	// Elements are the journal fields to read; defaults to work_notes and comments.
+	Elements []string
+
+	// FromDisplayValue reads the journal from the display value of the fields
+	// on the record itself instead of querying sys_journal_field, which often
+	// requires admin rights. Display values are rendered in the session user's
+	// date format and time zone; CreatedOn assumes the default format and UTC.
+	FromDisplayValue bool
+}
+
+type journalRecord struct {
+	SysID        string `json:"sys_id"`
+	Element      string `json:"element"`
+	Value        string `json:"value"`
+	SysCreatedBy string `json:"sys_created_by"`
+	SysCreatedOn string `json:"sys_created_on"`
+}
+
+// journalHeader matches the header of an entry in a journal display value:
+// "2024-01-15 10:00:00 - Fred Luddy (Work notes)".
+var journalHeader = regexp.MustCompile(`(?m)^(\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}) - (.+) \(([^()]+)\)\r?\n`)
+
+// AddWorkNote appends a work note to a task-based record.
+func (c *Client[T]) AddWorkNote(ctx context.Context, sysID, note string) error {
+	return c.addJournal(ctx, sysID, JournalWorkNotes, note)
+}
+
+// AddComment appends an additional comment to a task-based record.
+func (c *Client[T]) AddComment(ctx context.Context, sysID, comment string) error {
+	return c.addJournal(ctx, sysID, JournalComments, comment)
+}
+
+func (c *Client[T]) addJournal(ctx context.Context, sysID, element, value string) error {
+	if strings.TrimSpace(value) == "" {
+		return ErrEmptyJournalValue
+	}
+
+	recordPath, err := c.recordPath(sysID)
+	if err != nil {
+		return err
+	}
+
+	q := url.Values{"sysparm_fields": {"sys_id"}}
+	req, err := c.r.NewRequest(ctx, http.MethodPatch, recordPath, q, map[string]string{element: value})
+	if err != nil {
+		return err
+	}
+
+	return c.r.Do(req, nil)
+}
+
+// Journal returns the journal entries of the record sysID in chronological order.
+func (c *Client[T]) Journal(ctx context.Context, sysID string, opts *JournalOptions) ([]JournalEntry, error) {
+	if _, err := c.recordPath(sysID); err != nil {
+		return nil, err
+	}
+
+	elements := []string{JournalWorkNotes, JournalComments}
+	if opts != nil && len(opts.Elements) > 0 {
+		elements = opts.Elements
+	}
+
+	var (
+		entries []JournalEntry
+		err     error
+	)
+	if opts != nil && opts.FromDisplayValue {
+		entries, err = c.journalFromDisplayValue(ctx, sysID, elements)
+	} else {
+		entries, err = c.journalFromTable(ctx, sysID, elements)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(entries, func(i, k int) bool {
+		return entries[i].CreatedOn.Before(entries[k].CreatedOn)
+	})
+	return entries, nil
+}
+
+func (c *Client[T]) journalFromTable(ctx context.Context, sysID string, elements []string) ([]JournalEntry, error) {
+	journal, err := New[journalRecord](c.r, "sys_journal_field", WithStructFields())
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]any, len(elements))
+	for i, e := range elements {
+		values[i] = e
+	}
+	query, err := NewQueryBuilder().
+		Eq("element_id", strings.TrimSpace(sysID)).
+		Eq("name", c.table).
+		In("element", values...).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	query += "^ORDERBYsys_created_on"
+
+	var entries []JournalEntry
+	for offset := 0; ; offset += journalPageSize {
+		resp, err := journal.List(ctx, &ListOptions{
+			Query:                    query,
+			Limit:                    Int(journalPageSize),
+			Offset:                   Int(offset),
+			SuppressPaginationHeader: Bool(true),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, rec := range resp.Result {
+			createdOn, err := time.Parse(DateTimeLayout, rec.SysCreatedOn)
+			if err != nil {
+				return nil, fmt.Errorf("parse sys_created_on of journal entry %s: %w", rec.SysID, err)
+			}
+			entries = append(entries, JournalEntry{
+				SysID:     rec.SysID,
+				Element:   rec.Element,
+				Value:     rec.Value,
+				Author:    rec.SysCreatedBy,
+				CreatedOn: createdOn,
+			})
+		}
+
+		if len(resp.Result) < journalPageSize {
+			return entries, nil
+		}
+	}
+}
+
+func (c *Client[T]) journalFromDisplayValue(ctx context.Context, sysID string, elements []string) ([]JournalEntry, error) {
+	records, err := NewMap(c.r, c.table)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := records.Get(ctx, sysID, &GetOptions{
+		Fields:       elements,
+		DisplayValue: DisplayValue(DisplayValueTrue),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []JournalEntry
+	for _, element := range elements {
+		text, _ := resp.Result[element].(string)
+		entries = append(entries, parseJournalDisplayValue(element, text)...)
+	}
+	return entries, nil
+}
+
+// parseJournalDisplayValue splits the display value of a journal field into
+// entries. ServiceNow lists them newest first, separated by blank lines.
+func parseJournalDisplayValue(element, text string) []JournalEntry {
+	headers := journalHeader.FindAllStringSubmatchIndex(text, -1)
+
+	entries := make([]JournalEntry, 0, len(headers))
+	for i, h := range headers {
+		end := len(text)
+		if i+1 < len(headers) {
+			end = headers[i+1][0]
+		}
+
+		createdOn, _ := time.Parse(DateTimeLayout, text[h[2]:h[3]])
+		entries = append(entries, JournalEntry{
+			Element:   element,
+			Author:    text[h[4]:h[5]],
+			Value:     strings.TrimRight(text[h[1]:end], "\r\n"),
+			CreatedOn: createdOn,
+		})
+	}
+	return entries
+}
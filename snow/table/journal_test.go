@@ -0,0 +1,92 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestAddWorkNote(t *testing.T) {
+	c := newTestClient[map[string]any](t, "incident", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || r.URL.Path != "/api/now/table/incident/inc1" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("decode body: %v", err)
+			return
+		}
+		if len(body) != 1 || body["work_notes"] != "Rebooted the server" {
+			t.Errorf("body = %v", body)
+		}
+		_, _ = w.Write([]byte(`{"result":{"sys_id":"inc1"}}`))
+	})
+
+	if err := c.AddWorkNote(context.Background(), "inc1", "Rebooted the server"); err != nil {
+		t.Fatalf("AddWorkNote() error = %v", err)
+	}
+	if err := c.AddComment(context.Background(), "inc1", "  "); !errors.Is(err, ErrEmptyJournalValue) {
+		t.Fatalf("AddComment() error = %v, want ErrEmptyJournalValue", err)
+	}
+}
+
+func TestJournal(t *testing.T) {
+	c := newTestClient[map[string]any](t, "incident", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/now/table/sys_journal_field" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		want := "element_id=inc1^name=incident^elementINwork_notes,comments^ORDERBYsys_created_on"
+		if got := r.URL.Query().Get("sysparm_query"); got != want {
+			t.Errorf("sysparm_query = %q, want %q", got, want)
+		}
+		_, _ = w.Write([]byte(`{"result":[
+			{"sys_id":"j1","element":"comments","value":"Any update?","sys_created_by":"abel.tuter","sys_created_on":"2024-01-15 09:00:00"},
+			{"sys_id":"j2","element":"work_notes","value":"Rebooted","sys_created_by":"beth.anglin","sys_created_on":"2024-01-15 10:30:00"}
+		]}`))
+	})
+
+	entries, err := c.Journal(context.Background(), "inc1", nil)
+	if err != nil {
+		t.Fatalf("Journal() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("len(entries) = %d, want 2", len(entries))
+	}
+	if e := entries[1]; e.SysID != "j2" || e.Element != "work_notes" || e.Author != "beth.anglin" || e.CreatedOn.Hour() != 10 {
+		t.Errorf("entries[1] = %+v", e)
+	}
+}
+
+func TestJournalFromDisplayValue(t *testing.T) {
+	c := newTestClient[map[string]any](t, "incident", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if r.URL.Path != "/api/now/table/incident/inc1" || q.Get("sysparm_display_value") != "true" {
+			t.Errorf("request = %s?%s", r.URL.Path, r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"result":{
+			"work_notes":"2024-01-15 10:30:00 - Beth Anglin (Work notes)\nRebooted\nand checked logs\n\n2024-01-15 08:00:00 - Beth Anglin (Work notes)\nInvestigating\n\n",
+			"comments":"2024-01-15 09:00:00 - Abel Tuter (Additional comments)\nAny update?\n\n"
+		}}`))
+	})
+
+	entries, err := c.Journal(context.Background(), "inc1", &JournalOptions{FromDisplayValue: true})
+	if err != nil {
+		t.Fatalf("Journal() error = %v", err)
+	}
+
+	want := []JournalEntry{
+		{Element: "work_notes", Author: "Beth Anglin", Value: "Investigating"},
+		{Element: "comments", Author: "Abel Tuter", Value: "Any update?"},
+		{Element: "work_notes", Author: "Beth Anglin", Value: "Rebooted\nand checked logs"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("len(entries) = %d, want %d", len(entries), len(want))
+	}
+	for i, e := range entries {
+		if e.Element != want[i].Element || e.Author != want[i].Author || e.Value != want[i].Value || e.CreatedOn.IsZero() {
+			t.Errorf("entries[%d] = %+v, want %+v", i, e, want[i])
+		}
+	}
+}
@@ -14,6 +14,49 @@ var (
 	ErrInvalidOffset            = errors.New("Offset must be >= 0")
 )
 
+// Option configures a table Client at construction time.
+type Option func(*config) error
+
+type config struct {
+	structFields bool
+	dotWalk      bool
+	undo         *UndoJournal
+}
+
+// WithStructFields derives sysparm_fields from the json tags of T (see
+// StructFields) for every call that does not set Fields explicitly.
+func WithStructFields() Option {
+	return func(c *config) error {
+		c.structFields = true
+		return nil
+	}
+}
+
+// WithDotWalking decodes records with UnmarshalDotWalked, so flat dot-walked
+// keys ("caller_id.name") fill nested struct fields tagged `json:"caller_id"`.
+// Write inputs of type T are encoded with MarshalDotWalked, dropping the
+// dot-walked fields, which the Table API cannot write. Combined with
+// WithStructFields, nested struct fields are requested as dot-walked names.
+func WithDotWalking() Option {
+	return func(c *config) error {
+		c.dotWalk = true
+		return nil
+	}
+}
+
+// WithUndoJournal appends the before-image of every record to j before it is
+// updated, replaced or deleted, so the writes can be reverted with Rollback.
+// Each write costs an extra Get.
+func WithUndoJournal(j *UndoJournal) Option {
+	return func(c *config) error {
+		if j == nil {
+			return ErrNilUndoJournal
+		}
+		c.undo = j
+		return nil
+	}
+}
+
 // ListOptions provides ergonomic API for list queries
 type ListOptions struct {
 	// Filtering (mutually exclusive)
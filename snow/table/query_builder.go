@@ -3,17 +3,22 @@ package table
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var (
 	ErrEmptyQueryField      = errors.New("query field cannot be empty")
 	ErrInvalidQueryField    = errors.New("query field contains invalid characters")
+	ErrUnknownQueryField    = errors.New("query field is not a field of the record type")
 	ErrEmptyQueryValue      = errors.New("query value cannot be empty")
+	ErrInvalidQueryValue    = errors.New("query value contains invalid characters")
 	ErrEmptyQueryValues     = errors.New("query requires at least one value")
 	ErrEmptyQueryOperator   = errors.New("query operator cannot be empty")
 	ErrInvalidQueryLogical  = errors.New("logical operator cannot be the first query token")
 	ErrDanglingQueryLogical = errors.New("query ends with a dangling logical operator")
+	ErrInvalidNumberRange   = errors.New("number range high must not be below low")
 )
 
 // QueryBuilder composes a sysparm_query encoded query string.
@@ -26,18 +31,37 @@ var (
 //		Eq("priority", 1).
 //		Build()
 type QueryBuilder struct {
-	parts        []string
-	nextOperator string
-	err          error
+	terms           []queryTerm
+	nextOperator    string
+	defaultOperator string
+	fields          map[string]bool
+	orderBy         []string
+	err             error
 }
 
 // NewQueryBuilder creates a new encoded-query builder.
 func NewQueryBuilder() *QueryBuilder {
 	return &QueryBuilder{
-		nextOperator: "^",
+		nextOperator:    "^",
+		defaultOperator: "^",
 	}
 }
 
+// NewQueryBuilderFor creates a builder that only accepts the fields of T, as
+// reported by StructFields. Dot-walked names are accepted when their reference
+// field is known. Unknown fields make Build return ErrUnknownQueryField.
+func NewQueryBuilderFor[T any]() *QueryBuilder {
+	b := NewQueryBuilder()
+
+	names := StructFields[T]()
+	b.fields = make(map[string]bool, len(names))
+	for _, name := range names {
+		b.fields[name] = true
+	}
+
+	return b
+}
+
 func (b *QueryBuilder) Eq(field string, value any) *QueryBuilder {
 	return b.addBinary(field, "=", value)
 }
@@ -70,6 +94,73 @@ func (b *QueryBuilder) EndsWith(field string, value any) *QueryBuilder {
 }
 func (b *QueryBuilder) IsEmpty(field string) *QueryBuilder    { return b.addUnary(field, "ISEMPTY") }
 func (b *QueryBuilder) IsNotEmpty(field string) *QueryBuilder { return b.addUnary(field, "ISNOTEMPTY") }
+func (b *QueryBuilder) IsEmptyString(field string) *QueryBuilder {
+	return b.addUnary(field, "EMPTYSTRING")
+}
+
+// Anything matches every value of field, including empty ones.
+func (b *QueryBuilder) Anything(field string) *QueryBuilder { return b.addUnary(field, "ANYTHING") }
+
+// SameAs matches records where field equals otherField on the same record.
+func (b *QueryBuilder) SameAs(field, otherField string) *QueryBuilder {
+	return b.addFieldComparison(field, "SAMEAS", otherField)
+}
+func (b *QueryBuilder) NotSameAs(field, otherField string) *QueryBuilder {
+	return b.addFieldComparison(field, "NSAMEAS", otherField)
+}
+func (b *QueryBuilder) GTField(field, otherField string) *QueryBuilder {
+	return b.addFieldComparison(field, "GT_FIELD", otherField)
+}
+func (b *QueryBuilder) GTEField(field, otherField string) *QueryBuilder {
+	return b.addFieldComparison(field, "GT_OR_EQUALS_FIELD", otherField)
+}
+func (b *QueryBuilder) LTField(field, otherField string) *QueryBuilder {
+	return b.addFieldComparison(field, "LT_FIELD", otherField)
+}
+func (b *QueryBuilder) LTEField(field, otherField string) *QueryBuilder {
+	return b.addFieldComparison(field, "LT_OR_EQUALS_FIELD", otherField)
+}
+
+// Dynamic applies a dynamic filter option (sys_filter_option_dynamic) by sys_id,
+// e.g. DynamicMe for reference fields pointing at the current user.
+func (b *QueryBuilder) Dynamic(field, filterSysID string) *QueryBuilder {
+	filterSysID = strings.TrimSpace(filterSysID)
+	if filterSysID == "" {
+		b.setErr(ErrEmptyQueryValue)
+		return b
+	}
+	if !isSysID(filterSysID) {
+		b.setErr(ErrInvalidQueryValue)
+		return b
+	}
+
+	return b.addBinary(field, "DYNAMIC", filterSysID)
+}
+
+// ValChanges, ChangesFrom and ChangesTo are only evaluated in business rule
+// and notification conditions; the Table API ignores them.
+func (b *QueryBuilder) ValChanges(field string) *QueryBuilder { return b.addUnary(field, "VALCHANGES") }
+func (b *QueryBuilder) ChangesFrom(field string, value any) *QueryBuilder {
+	return b.addBinary(field, "CHANGESFROM", value)
+}
+func (b *QueryBuilder) ChangesTo(field string, value any) *QueryBuilder {
+	return b.addBinary(field, "CHANGESTO", value)
+}
+
+// BetweenNumbers matches numeric values between low and high, inclusive.
+func (b *QueryBuilder) BetweenNumbers(field string, low, high float64) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if high < low {
+		b.setErr(ErrInvalidNumberRange)
+		return b
+	}
+
+	return b.addCondition(field + "BETWEEN" +
+		strconv.FormatFloat(low, 'f', -1, 64) + "@" + strconv.FormatFloat(high, 'f', -1, 64))
+}
 
 // Op appends a condition with a custom ServiceNow operator.
 func (b *QueryBuilder) Op(field, operator string, value any) *QueryBuilder {
@@ -90,6 +181,17 @@ func (b *QueryBuilder) NotIn(field string, values ...any) *QueryBuilder {
 	return b.addList(field, "NOT IN", values...)
 }
 
+// OrderBy sorts the results by field in ascending order. Sort keys apply in
+// the order they are added and are always encoded after the conditions.
+func (b *QueryBuilder) OrderBy(field string) *QueryBuilder {
+	return b.addOrderBy("ORDERBY", field)
+}
+
+// OrderByDesc sorts the results by field in descending order.
+func (b *QueryBuilder) OrderByDesc(field string) *QueryBuilder {
+	return b.addOrderBy("ORDERBYDESC", field)
+}
+
 // And sets AND conjunction for the next condition.
 func (b *QueryBuilder) And() *QueryBuilder {
 	return b.setLogical("^")
@@ -113,14 +215,23 @@ func (b *QueryBuilder) Build() (string, error) {
 	if b.err != nil {
 		return "", b.err
 	}
-	if len(b.parts) == 0 {
-		return "", nil
+	orderBy := strings.Join(b.orderBy, "^")
+	if len(b.terms) == 0 {
+		return orderBy, nil
 	}
-	if b.nextOperator != "^" {
+	if b.nextOperator != b.joiner() {
 		return "", ErrDanglingQueryLogical
 	}
 
-	return strings.Join(b.parts, ""), nil
+	form, err := b.form()
+	if err != nil {
+		return "", err
+	}
+
+	if orderBy != "" {
+		return form.String() + "^" + orderBy, nil
+	}
+	return form.String(), nil
 }
 
 // String returns the built encoded query and discards build errors.
@@ -137,7 +248,7 @@ func (b *QueryBuilder) Err() error {
 	if b.err != nil {
 		return b.err
 	}
-	if len(b.parts) > 0 && b.nextOperator != "^" {
+	if len(b.terms) > 0 && b.nextOperator != b.joiner() {
 		return ErrDanglingQueryLogical
 	}
 
@@ -148,7 +259,7 @@ func (b *QueryBuilder) setLogical(op string) *QueryBuilder {
 	if b == nil || b.err != nil {
 		return b
 	}
-	if len(b.parts) == 0 {
+	if len(b.terms) == 0 {
 		b.setErr(ErrInvalidQueryLogical)
 		return b
 	}
@@ -157,6 +268,19 @@ func (b *QueryBuilder) setLogical(op string) *QueryBuilder {
 	return b
 }
 
+func (b *QueryBuilder) addOrderBy(prefix, field string) *QueryBuilder {
+	if b == nil || b.err != nil {
+		return b
+	}
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+
+	b.orderBy = append(b.orderBy, prefix+field)
+	return b
+}
+
 func (b *QueryBuilder) addBinary(field, operator string, value any) *QueryBuilder {
 	field, ok := b.validField(field)
 	if !ok {
@@ -181,6 +305,19 @@ func (b *QueryBuilder) addUnary(field, operator string) *QueryBuilder {
 	return b.addCondition(field + operator)
 }
 
+func (b *QueryBuilder) addFieldComparison(field, operator, otherField string) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	otherField, ok = b.validField(otherField)
+	if !ok {
+		return b
+	}
+
+	return b.addCondition(field + operator + otherField)
+}
+
 func (b *QueryBuilder) addList(field, operator string, values ...any) *QueryBuilder {
 	field, ok := b.validField(field)
 	if !ok {
@@ -205,6 +342,20 @@ func (b *QueryBuilder) addList(field, operator string, values ...any) *QueryBuil
 }
 
 func (b *QueryBuilder) validField(field string) (string, bool) {
+	field, ok := b.validFieldName(field)
+	if !ok {
+		return "", false
+	}
+	if b.fields != nil && !knownField(b.fields, field) {
+		b.setErr(fmt.Errorf("%w: %s", ErrUnknownQueryField, field))
+		return "", false
+	}
+
+	return field, true
+}
+
+// validFieldName checks field syntax only, without the record-type check.
+func (b *QueryBuilder) validFieldName(field string) (string, bool) {
 	field = strings.TrimSpace(field)
 	if field == "" {
 		b.setErr(ErrEmptyQueryField)
@@ -227,18 +378,32 @@ func (b *QueryBuilder) addCondition(condition string) *QueryBuilder {
 		return b
 	}
 
-	if len(b.parts) == 0 {
-		b.parts = append(b.parts, condition)
-		b.nextOperator = "^"
+	return b.addForm(queryForm{{{condition}}})
+}
+
+func (b *QueryBuilder) addForm(form queryForm) *QueryBuilder {
+	if b == nil || b.err != nil {
 		return b
 	}
 
-	b.parts = append(b.parts, b.nextOperator+condition)
-	b.nextOperator = "^"
+	op := ""
+	if len(b.terms) > 0 {
+		op = b.nextOperator
+	}
+	b.terms = append(b.terms, queryTerm{op: op, form: form})
+	b.nextOperator = b.joiner()
 
 	return b
 }
 
+// joiner returns the operator used between conditions when none was set explicitly.
+func (b *QueryBuilder) joiner() string {
+	if b.defaultOperator == "" {
+		return "^"
+	}
+	return b.defaultOperator
+}
+
 func (b *QueryBuilder) setErr(err error) {
 	if b == nil || b.err != nil || err == nil {
 		return
@@ -246,12 +411,38 @@ func (b *QueryBuilder) setErr(err error) {
 	b.err = err
 }
 
+func isSysID(s string) bool {
+	if len(s) != 32 {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+	return true
+}
+
 func queryValue(value any) (string, error) {
 	if value == nil {
 		return "", ErrEmptyQueryValue
 	}
 
-	raw := fmt.Sprint(value)
+	var raw string
+	switch v := value.(type) {
+	case time.Time:
+		if v.IsZero() {
+			return "", ErrEmptyQueryValue
+		}
+		raw = v.UTC().Format(DateTimeLayout)
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return "", ErrEmptyQueryValue
+		}
+		raw = v.UTC().Format(DateTimeLayout)
+	default:
+		raw = fmt.Sprint(value)
+	}
 	if raw == "" {
 		return "", ErrEmptyQueryValue
 	}
@@ -0,0 +1,236 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// DateTimeLayout is the layout ServiceNow uses for glide_date_time values in UTC.
+const DateTimeLayout = "2006-01-02 15:04:05"
+
+const (
+	dateLayout = "2006-01-02"
+	timeLayout = "15:04:05"
+)
+
+var (
+	ErrInvalidDateRange     = errors.New("date range end must not be before start")
+	ErrInvalidDateUnit      = errors.New("invalid date unit")
+	ErrInvalidDatePart      = errors.New("invalid date part comparison")
+	ErrInvalidRelativeValue = errors.New("relative amount must be >= 0")
+	ErrInvalidRelativeDir   = errors.New("relative direction must be 'ago' or 'ahead'")
+)
+
+// DateUnit is a unit accepted by relative and DATEPART date conditions.
+type DateUnit string
+
+const (
+	DateUnitMinute  DateUnit = "minute"
+	DateUnitHour    DateUnit = "hour"
+	DateUnitDay     DateUnit = "dayofweek"
+	DateUnitWeek    DateUnit = "week"
+	DateUnitMonth   DateUnit = "month"
+	DateUnitQuarter DateUnit = "quarter"
+	DateUnitYear    DateUnit = "year"
+)
+
+func (u DateUnit) Validate() error {
+	switch u {
+	case DateUnitMinute, DateUnitHour, DateUnitDay, DateUnitWeek, DateUnitMonth, DateUnitQuarter, DateUnitYear:
+		return nil
+	default:
+		return ErrInvalidDateUnit
+	}
+}
+
+// RelativeDirection tells a relative condition whether to count into the past or the future.
+type RelativeDirection string
+
+const (
+	RelativeAgo   RelativeDirection = "ago"
+	RelativeAhead RelativeDirection = "ahead"
+)
+
+func (d RelativeDirection) Validate() error {
+	switch d {
+	case RelativeAgo, RelativeAhead:
+		return nil
+	default:
+		return ErrInvalidRelativeDir
+	}
+}
+
+// DatePartComparison is the comparison used by a DATEPART condition.
+type DatePartComparison string
+
+const (
+	DatePartOn     DatePartComparison = "EE"
+	DatePartAfter  DatePartComparison = "GT"
+	DatePartBefore DatePartComparison = "LT"
+)
+
+func (c DatePartComparison) Validate() error {
+	switch c {
+	case DatePartOn, DatePartAfter, DatePartBefore:
+		return nil
+	default:
+		return ErrInvalidDatePart
+	}
+}
+
+// On matches values that fall on the calendar day of t (in UTC).
+func (b *QueryBuilder) On(field string, t time.Time) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if t.IsZero() {
+		b.setErr(ErrEmptyQueryValue)
+		return b
+	}
+
+	day := t.UTC().Format(dateLayout)
+	return b.addCondition(fmt.Sprintf("%sON%s@%s@%s",
+		field, day, dateGenerate(day, "start"), dateGenerate(day, "end")))
+}
+
+// Before matches values strictly before t.
+func (b *QueryBuilder) Before(field string, t time.Time) *QueryBuilder {
+	return b.addDateTime(field, "<", t)
+}
+
+// After matches values strictly after t.
+func (b *QueryBuilder) After(field string, t time.Time) *QueryBuilder {
+	return b.addDateTime(field, ">", t)
+}
+
+// Between matches values between from and to, inclusive.
+func (b *QueryBuilder) Between(field string, from, to time.Time) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if from.IsZero() || to.IsZero() {
+		b.setErr(ErrEmptyQueryValue)
+		return b
+	}
+	if to.Before(from) {
+		b.setErr(ErrInvalidDateRange)
+		return b
+	}
+
+	return b.addCondition(field + "BETWEEN" + dateTimeGenerate(from) + "@" + dateTimeGenerate(to))
+}
+
+// RelativeGT matches values after the point n units ago (or ahead).
+//
+//	RelativeGT("sys_updated_on", 3, DateUnitHour, RelativeAgo) // updated in the last 3 hours
+func (b *QueryBuilder) RelativeGT(field string, n int, unit DateUnit, dir RelativeDirection) *QueryBuilder {
+	return b.addRelative(field, "RELATIVEGT", n, unit, dir)
+}
+
+// RelativeLT matches values before the point n units ago (or ahead).
+func (b *QueryBuilder) RelativeLT(field string, n int, unit DateUnit, dir RelativeDirection) *QueryBuilder {
+	return b.addRelative(field, "RELATIVELT", n, unit, dir)
+}
+
+// DatePart matches on a single component of a date, e.g. the day of the week or the month.
+//
+//	DatePart("opened_at", DateUnitDay, "monday", DatePartOn)
+func (b *QueryBuilder) DatePart(field string, unit DateUnit, value string, cmp DatePartComparison) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if err := unit.Validate(); err != nil {
+		b.setErr(err)
+		return b
+	}
+	if err := cmp.Validate(); err != nil {
+		b.setErr(err)
+		return b
+	}
+
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		b.setErr(ErrEmptyQueryValue)
+		return b
+	}
+	if strings.ContainsAny(value, "'^@") {
+		b.setErr(ErrInvalidQueryValue)
+		return b
+	}
+
+	label := strings.ToUpper(value[:1]) + value[1:]
+	return b.addCondition(fmt.Sprintf("%sDATEPART%s@javascript:gs.datePart('%s','%s','%s')",
+		field, label, unit, value, cmp))
+}
+
+// DaysAgoStart matches values on or after the start of the day n days ago.
+func (b *QueryBuilder) DaysAgoStart(field string, n int) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if n < 0 {
+		b.setErr(ErrInvalidRelativeValue)
+		return b
+	}
+
+	return b.addCondition(fmt.Sprintf("%s>=javascript:gs.daysAgoStart(%d)", field, n))
+}
+
+// Today matches values between the beginning and the end of the current day.
+func (b *QueryBuilder) Today(field string) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+
+	return b.addCondition(field + "ONToday@javascript:gs.beginningOfToday()@javascript:gs.endOfToday()")
+}
+
+func (b *QueryBuilder) addDateTime(field, operator string, t time.Time) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if t.IsZero() {
+		b.setErr(ErrEmptyQueryValue)
+		return b
+	}
+
+	return b.addCondition(field + operator + dateTimeGenerate(t))
+}
+
+func (b *QueryBuilder) addRelative(field, operator string, n int, unit DateUnit, dir RelativeDirection) *QueryBuilder {
+	field, ok := b.validField(field)
+	if !ok {
+		return b
+	}
+	if n < 0 {
+		b.setErr(ErrInvalidRelativeValue)
+		return b
+	}
+	if err := unit.Validate(); err != nil {
+		b.setErr(err)
+		return b
+	}
+	if err := dir.Validate(); err != nil {
+		b.setErr(err)
+		return b
+	}
+
+	return b.addCondition(fmt.Sprintf("%s%s@%s@%s@%d", field, operator, unit, dir, n))
+}
+
+func dateGenerate(date, clock string) string {
+	return fmt.Sprintf("javascript:gs.dateGenerate('%s','%s')", date, clock)
+}
+
+func dateTimeGenerate(t time.Time) string {
+	t = t.UTC()
+	return dateGenerate(t.Format(dateLayout), t.Format(timeLayout))
+}
@@ -0,0 +1,223 @@
+package table
+
+import (
+	"errors"
+	"strings"
+)
+
+// maxQueryGroups caps how many ^NQ groups a query may expand to when nested
+// groups are distributed.
+const maxQueryGroups = 64
+
+var (
+	ErrEmptyQueryGroup = errors.New("query group has no conditions")
+	ErrQueryTooComplex = errors.New("query expands to too many ^NQ groups to encode")
+	ErrNilQueryGroup   = errors.New("query group function is nil")
+)
+
+// AnyOf appends a group whose conditions are OR'ed together.
+//
+// Conditions added to the group builder are joined with ^OR unless And() or
+// NewQuery() is used inside the group. Groups can be nested:
+//
+//	// (state=1 OR state=2) AND (priority=1 OR urgency=1)
+//	query, err := table.NewQueryBuilder().
+//		AnyOf(func(q *table.QueryBuilder) { q.Eq("state", 1).Eq("state", 2) }).
+//		AnyOf(func(q *table.QueryBuilder) { q.Eq("priority", 1).Eq("urgency", 1) }).
+//		Build()
+func (b *QueryBuilder) AnyOf(fn func(*QueryBuilder)) *QueryBuilder {
+	return b.addGroup("^OR", fn)
+}
+
+// AllOf appends a group whose conditions are AND'ed together.
+//
+// It is mostly useful inside AnyOf, e.g. (a AND b) OR c.
+func (b *QueryBuilder) AllOf(fn func(*QueryBuilder)) *QueryBuilder {
+	return b.addGroup("^", fn)
+}
+
+func (b *QueryBuilder) addGroup(joiner string, fn func(*QueryBuilder)) *QueryBuilder {
+	if b == nil || b.err != nil {
+		return b
+	}
+	if fn == nil {
+		b.setErr(ErrNilQueryGroup)
+		return b
+	}
+
+	sub := &QueryBuilder{
+		nextOperator:    joiner,
+		defaultOperator: joiner,
+		fields:          b.fields,
+	}
+	fn(sub)
+	if err := sub.Err(); err != nil {
+		b.setErr(err)
+		return b
+	}
+	if len(sub.terms) == 0 {
+		b.setErr(ErrEmptyQueryGroup)
+		return b
+	}
+
+	form, err := sub.form()
+	if err != nil {
+		b.setErr(err)
+		return b
+	}
+
+	b.orderBy = append(b.orderBy, sub.orderBy...)
+	return b.addForm(form)
+}
+
+// queryTerm is a condition or group together with the operator that joins it
+// to the previous term. The first term has no operator.
+type queryTerm struct {
+	op   string
+	form queryForm
+}
+
+// queryForm is an encoded query in the shape ServiceNow can express:
+// ^NQ-separated conjunctions of ^-separated clauses of ^OR-separated conditions.
+type queryForm [][][]string
+
+func (f queryForm) String() string {
+	conjs := make([]string, 0, len(f))
+	for _, conj := range f {
+		clauses := make([]string, 0, len(conj))
+		for _, clause := range conj {
+			clauses = append(clauses, strings.Join(clause, "^OR"))
+		}
+		conjs = append(conjs, strings.Join(clauses, "^"))
+	}
+	return strings.Join(conjs, "^NQ")
+}
+
+func (f queryForm) hasRelatedList() bool {
+	for _, conj := range f {
+		for _, clause := range conj {
+			for _, condition := range clause {
+				if isRelatedListCondition(condition) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// form evaluates the builder terms with encoded-query precedence
+// (^OR binds tighter than ^, which binds tighter than ^NQ).
+func (b *QueryBuilder) form() (queryForm, error) {
+	var (
+		out      queryForm
+		clauses  []queryForm
+		operands []queryForm
+	)
+
+	closeClause := func() error {
+		clause, err := orForms(operands)
+		if err != nil {
+			return err
+		}
+		clauses = append(clauses, clause)
+		operands = nil
+		return nil
+	}
+	closeQuery := func() error {
+		if err := closeClause(); err != nil {
+			return err
+		}
+		conj, err := andForms(clauses)
+		if err != nil {
+			return err
+		}
+		out = append(out, conj...)
+		clauses = nil
+		if len(out) > maxQueryGroups {
+			return ErrQueryTooComplex
+		}
+		return nil
+	}
+
+	for i, term := range b.terms {
+		if i > 0 {
+			switch term.op {
+			case "^":
+				if err := closeClause(); err != nil {
+					return nil, err
+				}
+			case "^NQ":
+				if err := closeQuery(); err != nil {
+					return nil, err
+				}
+			}
+		}
+		operands = append(operands, term.form)
+	}
+	if err := closeQuery(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// orForms ORs forms together. Plain clauses are merged into a single ^OR
+// clause; anything else is split into ^NQ groups.
+func orForms(forms []queryForm) (queryForm, error) {
+	if len(forms) > 1 {
+		for _, f := range forms {
+			if f.hasRelatedList() {
+				return nil, ErrInvalidRelatedQuery
+			}
+		}
+	}
+
+	simple := true
+	for _, f := range forms {
+		if len(f) != 1 || len(f[0]) != 1 {
+			simple = false
+			break
+		}
+	}
+
+	if simple {
+		var clause []string
+		for _, f := range forms {
+			clause = append(clause, f[0][0]...)
+		}
+		return queryForm{{clause}}, nil
+	}
+
+	var out queryForm
+	for _, f := range forms {
+		out = append(out, f...)
+	}
+	if len(out) > maxQueryGroups {
+		return nil, ErrQueryTooComplex
+	}
+
+	return out, nil
+}
+
+// andForms ANDs forms together, distributing over their ^NQ groups.
+func andForms(forms []queryForm) (queryForm, error) {
+	out := queryForm{{}}
+	for _, f := range forms {
+		next := make(queryForm, 0, len(out)*len(f))
+		for _, left := range out {
+			for _, right := range f {
+				conj := make([][]string, 0, len(left)+len(right))
+				conj = append(conj, left...)
+				conj = append(conj, right...)
+				next = append(next, conj)
+			}
+		}
+		if len(next) > maxQueryGroups {
+			return nil, ErrQueryTooComplex
+		}
+		out = next
+	}
+
+	return out, nil
+}
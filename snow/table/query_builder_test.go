@@ -3,6 +3,7 @@ package table
 import (
 	"errors"
 	"testing"
+	"time"
 )
 
 func TestQueryBuilderBuild(t *testing.T) {
@@ -103,3 +104,265 @@ func TestQueryBuilderInRequiresValues(t *testing.T) {
 		t.Fatalf("Build() error = %v, want %v", err, ErrEmptyQueryValues)
 	}
 }
+
+func TestQueryBuilderFormatsTime(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 12, 30, 0, 0, time.FixedZone("CET", 3600))
+	query, err := NewQueryBuilder().
+		GTE("sys_updated_on", ts).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	const want = "sys_updated_on>=2024-01-15 11:30:00"
+	if query != want {
+		t.Fatalf("Build() = %q, want %q", query, want)
+	}
+}
+
+func TestQueryBuilderDateConditions(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		b    *QueryBuilder
+		want string
+	}{
+		{
+			name: "on",
+			b:    NewQueryBuilder().On("sys_created_on", from),
+			want: "sys_created_onON2024-01-01@javascript:gs.dateGenerate('2024-01-01','start')@javascript:gs.dateGenerate('2024-01-01','end')",
+		},
+		{
+			name: "before",
+			b:    NewQueryBuilder().Before("sys_created_on", to),
+			want: "sys_created_on<javascript:gs.dateGenerate('2024-01-31','23:59:59')",
+		},
+		{
+			name: "after",
+			b:    NewQueryBuilder().After("sys_created_on", from),
+			want: "sys_created_on>javascript:gs.dateGenerate('2024-01-01','00:00:00')",
+		},
+		{
+			name: "between",
+			b:    NewQueryBuilder().Between("sys_created_on", from, to),
+			want: "sys_created_onBETWEENjavascript:gs.dateGenerate('2024-01-01','00:00:00')@javascript:gs.dateGenerate('2024-01-31','23:59:59')",
+		},
+		{
+			name: "relative",
+			b:    NewQueryBuilder().RelativeGT("sys_updated_on", 3, DateUnitHour, RelativeAgo),
+			want: "sys_updated_onRELATIVEGT@hour@ago@3",
+		},
+		{
+			name: "datepart",
+			b:    NewQueryBuilder().DatePart("opened_at", DateUnitDay, "Monday", DatePartOn),
+			want: "opened_atDATEPARTMonday@javascript:gs.datePart('dayofweek','monday','EE')",
+		},
+		{
+			name: "days ago",
+			b:    NewQueryBuilder().DaysAgoStart("sys_created_on", 7),
+			want: "sys_created_on>=javascript:gs.daysAgoStart(7)",
+		},
+		{
+			name: "today",
+			b:    NewQueryBuilder().Today("sys_created_on"),
+			want: "sys_created_onONToday@javascript:gs.beginningOfToday()@javascript:gs.endOfToday()",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := tt.b.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if query != tt.want {
+				t.Fatalf("Build() = %q, want %q", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderBetweenRejectsInvertedRange(t *testing.T) {
+	from := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	_, err := NewQueryBuilder().
+		Between("sys_created_on", from, from.Add(-time.Hour)).
+		Build()
+	if !errors.Is(err, ErrInvalidDateRange) {
+		t.Fatalf("Build() error = %v, want %v", err, ErrInvalidDateRange)
+	}
+}
+
+func TestQueryBuilderGroups(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *QueryBuilder
+		want string
+	}{
+		{
+			name: "or groups and'ed",
+			b: NewQueryBuilder().
+				AnyOf(func(q *QueryBuilder) { q.Eq("state", 1).Eq("state", 2) }).
+				AnyOf(func(q *QueryBuilder) { q.Eq("priority", 1).Eq("urgency", 1) }),
+			want: "state=1^ORstate=2^priority=1^ORurgency=1",
+		},
+		{
+			name: "and groups or'ed",
+			b: NewQueryBuilder().
+				AnyOf(func(q *QueryBuilder) {
+					q.AllOf(func(q *QueryBuilder) { q.Eq("a", 1).Eq("b", 2) }).
+						AllOf(func(q *QueryBuilder) { q.Eq("c", 3).Eq("d", 4) })
+				}),
+			want: "a=1^b=2^NQc=3^d=4",
+		},
+		{
+			name: "distributes over NQ",
+			b: NewQueryBuilder().
+				Eq("active", true).
+				AnyOf(func(q *QueryBuilder) {
+					q.AllOf(func(q *QueryBuilder) { q.Eq("a", 1).Eq("b", 2) }).
+						Eq("c", 3)
+				}),
+			want: "active=true^a=1^b=2^NQactive=true^c=3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := tt.b.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if query != tt.want {
+				t.Fatalf("Build() = %q, want %q", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderEmptyGroup(t *testing.T) {
+	_, err := NewQueryBuilder().
+		AnyOf(func(*QueryBuilder) {}).
+		Build()
+	if !errors.Is(err, ErrEmptyQueryGroup) {
+		t.Fatalf("Build() error = %v, want %v", err, ErrEmptyQueryGroup)
+	}
+}
+
+func TestQueryBuilderGroupTooComplex(t *testing.T) {
+	b := NewQueryBuilder()
+	for i := 0; i < 7; i++ {
+		b.AnyOf(func(q *QueryBuilder) {
+			q.AllOf(func(q *QueryBuilder) { q.Eq("a", i).Eq("b", i) }).
+				AllOf(func(q *QueryBuilder) { q.Eq("c", i).Eq("d", i) })
+		})
+	}
+
+	_, err := b.Build()
+	if !errors.Is(err, ErrQueryTooComplex) {
+		t.Fatalf("Build() error = %v, want %v", err, ErrQueryTooComplex)
+	}
+}
+
+func TestQueryBuilderFieldOperators(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *QueryBuilder
+		want string
+	}{
+		{"same as", NewQueryBuilder().SameAs("opened_by", "caller_id"), "opened_bySAMEAScaller_id"},
+		{"not same as", NewQueryBuilder().NotSameAs("opened_by", "caller_id"), "opened_byNSAMEAScaller_id"},
+		{"gt field", NewQueryBuilder().GTField("sys_updated_on", "sys_created_on"), "sys_updated_onGT_FIELDsys_created_on"},
+		{"lt field", NewQueryBuilder().LTField("due_date", "closed_at"), "due_dateLT_FIELDclosed_at"},
+		{"dynamic", NewQueryBuilder().Dynamic("assigned_to", DynamicMe), "assigned_toDYNAMIC90d1921e5f510100a9ad2572f2b477fe"},
+		{"anything", NewQueryBuilder().Anything("category"), "categoryANYTHING"},
+		{"empty string", NewQueryBuilder().IsEmptyString("category"), "categoryEMPTYSTRING"},
+		{"val changes", NewQueryBuilder().ValChanges("state"), "stateVALCHANGES"},
+		{"changes from", NewQueryBuilder().ChangesFrom("state", 1), "stateCHANGESFROM1"},
+		{"changes to", NewQueryBuilder().ChangesTo("state", 7), "stateCHANGESTO7"},
+		{"between numbers", NewQueryBuilder().BetweenNumbers("priority", 1, 3), "priorityBETWEEN1@3"},
+		{
+			"related list",
+			NewQueryBuilder().HasRelated("incident_task", "incident", func(q *QueryBuilder) { q.Eq("active", true) }),
+			"RLQUERYincident_task.incident,>=1^active=true^ENDRLQUERY",
+		},
+		{
+			"related list without filter",
+			NewQueryBuilder().Eq("active", true).HasNoRelated("incident_task", "incident", nil),
+			"active=true^RLQUERYincident_task.incident,=0^ENDRLQUERY",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := tt.b.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if query != tt.want {
+				t.Fatalf("Build() = %q, want %q", query, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderFieldOperatorValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *QueryBuilder
+		want error
+	}{
+		{"same as empty field", NewQueryBuilder().SameAs("opened_by", " "), ErrEmptyQueryField},
+		{"dynamic bad sys_id", NewQueryBuilder().Dynamic("assigned_to", "me"), ErrInvalidQueryValue},
+		{"between numbers inverted", NewQueryBuilder().BetweenNumbers("priority", 3, 1), ErrInvalidNumberRange},
+		{"related bad operator", NewQueryBuilder().RelatedList("incident_task", "incident", "~", 1, nil), ErrInvalidRelatedOperator},
+		{"related negative count", NewQueryBuilder().RelatedList("incident_task", "incident", ">", -1, nil), ErrInvalidRelatedCount},
+		{
+			"related or'ed",
+			NewQueryBuilder().Eq("active", true).Or().HasRelated("incident_task", "incident", nil),
+			ErrInvalidRelatedQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.Build()
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Build() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryBuilderOrderBy(t *testing.T) {
+	tests := []struct {
+		name string
+		b    *QueryBuilder
+		want string
+	}{
+		{"only order", NewQueryBuilder().OrderBy("number"), "ORDERBYnumber"},
+		{
+			"after conditions",
+			NewQueryBuilder().OrderByDesc("sys_updated_on").Eq("active", true).OrderBy("sys_id"),
+			"active=true^ORDERBYDESCsys_updated_on^ORDERBYsys_id",
+		},
+		{
+			"after new query",
+			NewQueryBuilder().Eq("state", 1).NewQuery().Eq("state", 2).OrderBy("number"),
+			"state=1^NQstate=2^ORDERBYnumber",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.b.Build()
+			if err != nil {
+				t.Fatalf("Build() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("Build() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+const defaultSyncPageSize = 1000
+
+var (
+	ErrInvalidSyncQuery = errors.New("sync query cannot contain ^NQ or ORDERBY")
+	ErrNilSyncHandler   = errors.New("sync handler is nil")
+)
+
+// SyncOptions configures Changes and Sync.
+type SyncOptions struct {
+	// Query filters the records, e.g. "active=true". It is combined with the
+	// checkpoint condition, so it cannot contain ^NQ or ORDERBY.
+	Query string
+	// Fields limits the returned fields; sys_id, sys_updated_on and
+	// sys_mod_count are always requested.
+	Fields   []string
+	PageSize int // Records per request; defaults to 1000
+
+	// Since is where Sync starts when Store has no checkpoint.
+	Since Checkpoint
+	// Store persists the checkpoint after every page handled by Sync.
+	Store CheckpointStore
+	// Key is the Store key; defaults to the table name.
+	Key string
+}
+
+// ChangesResponse is a page of records changed after a checkpoint.
+type ChangesResponse[T any] struct {
+	Result []T
+	// Next is the checkpoint of the last record, or the requested one if
+	// Result is empty.
+	Next Checkpoint
+	// More reports whether the page was full, so more changes may follow.
+	More bool
+}
+
+// syncMetaFields are always requested by Changes.
+var syncMetaFields = []string{"sys_id", "sys_updated_on", "sys_mod_count"}
+
+type syncMeta struct {
+	SysID     string `json:"sys_id"`
+	UpdatedOn string `json:"sys_updated_on"`
+	ModCount  string `json:"sys_mod_count"`
+
+	updatedOn time.Time
+}
+
+// Changes returns the next page of records inserted or updated after since,
+// ordered by sys_updated_on and sys_id. Records updated in the same second as
+// the checkpoint are not skipped: they are compared by sys_id. Pass Next as
+// since to read the following page.
+func (c *Client[T]) Changes(ctx context.Context, since Checkpoint, opts *SyncOptions) (*ChangesResponse[T], error) {
+	page, _, err := c.changes(ctx, since, opts)
+	return page, err
+}
+
+// changes is Changes, also returning the system fields of every record.
+func (c *Client[T]) changes(ctx context.Context, since Checkpoint, opts *SyncOptions) (*ChangesResponse[T], []syncMeta, error) {
+	o := SyncOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.PageSize < 0 {
+		return nil, nil, ErrInvalidLimit
+	}
+	if o.PageSize == 0 {
+		o.PageSize = defaultSyncPageSize
+	}
+
+	query, err := changesQuery(since, o.Query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fields := o.Fields
+	if len(fields) == 0 && c != nil && c.cfg.structFields {
+		fields = structFields(reflect.TypeFor[T](), c.cfg.dotWalk)
+	}
+	if len(fields) > 0 {
+		fields = slices.Clone(fields)
+		for _, f := range syncMetaFields {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+
+	raw, _, err := c.listRaw(ctx, &ListOptions{
+		Query:                    query,
+		Fields:                   fields,
+		Limit:                    Int(o.PageSize),
+		SuppressPaginationHeader: Bool(true),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	out := &ChangesResponse[T]{
+		Result: make([]T, len(raw)),
+		Next:   since,
+		More:   len(raw) == o.PageSize,
+	}
+	metas := make([]syncMeta, len(raw))
+	for i, rec := range raw {
+		if err := c.decode(rec, &out.Result[i]); err != nil {
+			return nil, nil, err
+		}
+
+		meta := &metas[i]
+		if err := json.Unmarshal(rec, meta); err != nil {
+			return nil, nil, err
+		}
+		if meta.updatedOn, err = time.Parse(DateTimeLayout, meta.UpdatedOn); err != nil {
+			return nil, nil, fmt.Errorf("parse sys_updated_on of %s: %w", meta.SysID, err)
+		}
+		out.Next = Checkpoint{UpdatedOn: meta.updatedOn, SysID: meta.SysID}
+	}
+
+	return out, metas, nil
+}
+
+// Sync reads all changes after the stored checkpoint page by page, passing
+// each page to handle and saving the checkpoint once handle returns nil. A
+// failed handle or save stops the sync; the page is delivered again by the
+// next Sync, so handle should be idempotent. It returns the last checkpoint
+// reached.
+func (c *Client[T]) Sync(ctx context.Context, opts *SyncOptions, handle func([]T) error) (Checkpoint, error) {
+	o := SyncOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if handle == nil {
+		return o.Since, ErrNilSyncHandler
+	}
+
+	key := o.Key
+	if key == "" {
+		key = c.Table()
+	}
+
+	cp := o.Since
+	if o.Store != nil {
+		stored, ok, err := o.Store.Load(ctx, key)
+		if err != nil {
+			return cp, err
+		}
+		if ok {
+			cp = stored
+		}
+	}
+
+	for {
+		page, err := c.Changes(ctx, cp, &o)
+		if err != nil {
+			return cp, err
+		}
+		if len(page.Result) > 0 {
+			if err := handle(page.Result); err != nil {
+				return cp, err
+			}
+			if o.Store != nil {
+				if err := o.Store.Save(ctx, key, page.Next); err != nil {
+					return cp, err
+				}
+			}
+			cp = page.Next
+		}
+		if !page.More {
+			return cp, nil
+		}
+	}
+}
+
+// changesQuery selects the records after since:
+// sys_updated_on>t, or sys_updated_on=t and sys_id>id.
+func changesQuery(since Checkpoint, filter string) (string, error) {
+	filter = strings.TrimSpace(filter)
+	if strings.Contains(filter, "^NQ") || strings.Contains(filter, "ORDERBY") {
+		return "", ErrInvalidSyncQuery
+	}
+
+	var halves []*QueryBuilder
+	switch {
+	case since.IsZero():
+	case since.SysID == "":
+		halves = append(halves, NewQueryBuilder().GTE("sys_updated_on", since.UpdatedOn))
+	default:
+		halves = append(halves,
+			NewQueryBuilder().GT("sys_updated_on", since.UpdatedOn),
+			NewQueryBuilder().Eq("sys_updated_on", since.UpdatedOn).GT("sys_id", since.SysID),
+		)
+	}
+
+	var parts []string
+	for _, b := range halves {
+		half, err := b.Build()
+		if err != nil {
+			return "", err
+		}
+		if filter != "" {
+			half += "^" + filter
+		}
+		parts = append(parts, half)
+	}
+	if len(parts) == 0 && filter != "" {
+		parts = append(parts, filter)
+	}
+
+	order, err := NewQueryBuilder().OrderBy("sys_updated_on").OrderBy("sys_id").Build()
+	if err != nil {
+		return "", err
+	}
+
+	query := strings.Join(parts, "^NQ")
+	if query == "" {
+		return order, nil
+	}
+	return query + "^" + order, nil
+}
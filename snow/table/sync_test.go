@@ -0,0 +1,102 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type syncTestRecord struct {
+	SysID  string `json:"sys_id"`
+	Number string `json:"number"`
+}
+
+func TestChangesQuery(t *testing.T) {
+	ts := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		since  Checkpoint
+		filter string
+		want   string
+	}{
+		{"start", Checkpoint{}, "", "ORDERBYsys_updated_on^ORDERBYsys_id"},
+		{"start filtered", Checkpoint{}, "active=true", "active=true^ORDERBYsys_updated_on^ORDERBYsys_id"},
+		{"time only", Checkpoint{UpdatedOn: ts}, "", "sys_updated_on>=2024-01-15 10:00:00^ORDERBYsys_updated_on^ORDERBYsys_id"},
+		{
+			"tiebreak",
+			Checkpoint{UpdatedOn: ts, SysID: "abc"},
+			"active=true",
+			"sys_updated_on>2024-01-15 10:00:00^active=true^NQsys_updated_on=2024-01-15 10:00:00^sys_id>abc^active=true" +
+				"^ORDERBYsys_updated_on^ORDERBYsys_id",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := changesQuery(tt.since, tt.filter)
+			if err != nil {
+				t.Fatalf("changesQuery() error = %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("changesQuery() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := changesQuery(Checkpoint{}, "active=true^NQactive=false"); !errors.Is(err, ErrInvalidSyncQuery) {
+		t.Fatalf("changesQuery() error = %v, want ErrInvalidSyncQuery", err)
+	}
+}
+
+func TestSync(t *testing.T) {
+	pages := map[string]string{
+		"ORDERBYsys_updated_on^ORDERBYsys_id": `{"result":[
+			{"sys_id":"a","number":"INC1","sys_updated_on":"2024-01-15 10:00:00"},
+			{"sys_id":"b","number":"INC2","sys_updated_on":"2024-01-15 10:00:00"}
+		]}`,
+		"sys_updated_on>2024-01-15 10:00:00^NQsys_updated_on=2024-01-15 10:00:00^sys_id>b^ORDERBYsys_updated_on^ORDERBYsys_id": `{"result":[
+			{"sys_id":"c","number":"INC3","sys_updated_on":"2024-01-15 10:00:00"}
+		]}`,
+	}
+
+	c := newTestClient[syncTestRecord](t, "incident", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("sysparm_fields") != "sys_id,number,sys_updated_on,sys_mod_count" || q.Get("sysparm_limit") != "2" {
+			t.Errorf("request = %s", r.URL.RawQuery)
+		}
+		page, ok := pages[q.Get("sysparm_query")]
+		if !ok {
+			t.Errorf("unexpected sysparm_query %q", q.Get("sysparm_query"))
+			page = `{"result":[]}`
+		}
+		w.Write([]byte(page))
+	}, WithStructFields())
+
+	store, err := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoints.json"))
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	var numbers []string
+	cp, err := c.Sync(context.Background(), &SyncOptions{PageSize: 2, Store: store}, func(records []syncTestRecord) error {
+		for _, r := range records {
+			numbers = append(numbers, r.Number)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Sync() error = %v", err)
+	}
+	if len(numbers) != 3 || numbers[2] != "INC3" || cp.SysID != "c" {
+		t.Fatalf("Sync() = %v, %+v", numbers, cp)
+	}
+
+	stored, ok, err := store.Load(context.Background(), "incident")
+	if err != nil || !ok || stored != cp {
+		t.Fatalf("Load() = %+v, %v, %v; want %+v", stored, ok, err, cp)
+	}
+}
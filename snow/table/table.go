@@ -2,10 +2,12 @@ package table
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 	"net/url"
 	"path"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -23,9 +25,10 @@ var (
 type Client[T any] struct {
 	r     snow.Requester
 	table string
+	cfg   config
 }
 
-func New[T any](r snow.Requester, tableName string) (*Client[T], error) {
+func New[T any](r snow.Requester, tableName string, opts ...Option) (*Client[T], error) {
 	tableName = strings.TrimSpace(tableName)
 
 	if r == nil {
@@ -35,15 +38,30 @@ func New[T any](r snow.Requester, tableName string) (*Client[T], error) {
 		return nil, ErrInvalidTableName
 	}
 
-	return &Client[T]{
+	c := &Client[T]{
 		r:     r,
 		table: tableName,
-	}, nil
+	}
+	for _, opt := range opts {
+		if err := opt(&c.cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
 }
 
 // NewMap is a convenience constructor returning dynamic records.
-func NewMap(r snow.Requester, tableName string) (*Client[map[string]any], error) {
-	return New[map[string]any](r, tableName)
+func NewMap(r snow.Requester, tableName string, opts ...Option) (*Client[map[string]any], error) {
+	return New[map[string]any](r, tableName, opts...)
+}
+
+// Table returns the name of the table the client is bound to.
+func (c *Client[T]) Table() string {
+	if c == nil {
+		return ""
+	}
+	return c.table
 }
 
 func (c *Client[T]) basePath() (string, error) {
@@ -72,38 +90,57 @@ func (c *Client[T]) recordPath(sysID string) (string, error) {
 
 // List retrieves multiple records with pagination metadata.
 func (c *Client[T]) List(ctx context.Context, opts *ListOptions) (*ListResponse[T], error) {
-	base, err := c.basePath()
+	raw, resp, err := c.listRaw(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
+	records := make([]T, len(raw))
+	for i, rec := range raw {
+		if err := c.decode(rec, &records[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	listResp := &ListResponse[T]{
+		Result: records,
+	}
+
+	if opts == nil || opts.SuppressPaginationHeader == nil || !*opts.SuppressPaginationHeader {
+		listResp.Meta = parsePaginationHeaders(resp.Header)
+	}
+
+	return listResp, nil
+}
+
+// listRaw lists records without decoding them.
+func (c *Client[T]) listRaw(ctx context.Context, opts *ListOptions) ([]json.RawMessage, *http.Response, error) {
+	base, err := c.basePath()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	q := url.Values{}
 	if opts != nil {
 		if err := opts.apply(q); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
+	c.applyDefaults(q)
+
 	req, err := c.r.NewRequest(ctx, http.MethodGet, base, q, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	var out resultList[T]
+	var out resultList[json.RawMessage]
 	resp, err := c.r.DoWithResponse(req, &out)
 	if err != nil {
-		return nil, err
-	}
-
-	listResp := &ListResponse[T]{
-		Result: out.Result,
-	}
-
-	if opts == nil || opts.SuppressPaginationHeader == nil || !*opts.SuppressPaginationHeader {
-		listResp.Meta = parsePaginationHeaders(resp.Header)
+		return nil, nil, err
 	}
 
-	return listResp, nil
+	return out.Result, resp, nil
 }
 
 func (c *Client[T]) Get(ctx context.Context, sysID string, opts *GetOptions) (*GetResponse[T], error) {
@@ -121,17 +158,24 @@ func (c *Client[T]) Get(ctx context.Context, sysID string, opts *GetOptions) (*G
 		}
 	}
 
+	c.applyDefaults(q)
+
 	req, err := c.r.NewRequest(ctx, http.MethodGet, recordPath, q, nil)
 	if err != nil {
 		return zero, err
 	}
 
-	var out resultOne[T]
+	var out resultOne[json.RawMessage]
 	if err := c.r.Do(req, &out); err != nil {
 		return zero, err
 	}
 
-	return &GetResponse[T]{Result: out.Result}, nil
+	var record T
+	if err := c.decode(out.Result, &record); err != nil {
+		return zero, err
+	}
+
+	return &GetResponse[T]{Result: record}, nil
 }
 
 func (c *Client[T]) Create(ctx context.Context, in any, opts *WriteOptions) (*WriteResponse[T], error) {
@@ -152,17 +196,29 @@ func (c *Client[T]) Create(ctx context.Context, in any, opts *WriteOptions) (*Wr
 		}
 	}
 
-	req, err := c.r.NewRequest(ctx, http.MethodPost, base, q, in)
+	c.applyDefaults(q)
+
+	body, err := c.encode(in)
+	if err != nil {
+		return zero, err
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodPost, base, q, body)
 	if err != nil {
 		return zero, err
 	}
 
-	var out resultOne[T]
+	var out resultOne[json.RawMessage]
 	if err := c.r.Do(req, &out); err != nil {
 		return zero, err
 	}
 
-	return &WriteResponse[T]{Result: out.Result}, nil
+	var record T
+	if err := c.decode(out.Result, &record); err != nil {
+		return zero, err
+	}
+
+	return &WriteResponse[T]{Result: record}, nil
 }
 
 func (c *Client[T]) Update(ctx context.Context, sysID string, in any, opts *WriteOptions) (*WriteResponse[T], error) {
@@ -183,17 +239,33 @@ func (c *Client[T]) Update(ctx context.Context, sysID string, in any, opts *Writ
 		}
 	}
 
-	req, err := c.r.NewRequest(ctx, http.MethodPatch, recordPath, q, in)
+	c.applyDefaults(q)
+
+	body, err := c.encode(in)
+	if err != nil {
+		return zero, err
+	}
+	undoID, err := c.captureUndo(ctx, UndoUpdate, sysID, body)
 	if err != nil {
 		return zero, err
 	}
 
-	var out resultOne[T]
+	req, err := c.r.NewRequest(ctx, http.MethodPatch, recordPath, q, body)
+	if err != nil {
+		return zero, c.abortUndo(undoID, err, false)
+	}
+
+	var out resultOne[json.RawMessage]
 	if err := c.r.Do(req, &out); err != nil {
+		return zero, c.abortUndo(undoID, err, true)
+	}
+
+	var record T
+	if err := c.decode(out.Result, &record); err != nil {
 		return zero, err
 	}
 
-	return &WriteResponse[T]{Result: out.Result}, nil
+	return &WriteResponse[T]{Result: record}, nil
 }
 
 func (c *Client[T]) Replace(ctx context.Context, sysID string, in any, opts *WriteOptions) (*WriteResponse[T], error) {
@@ -214,17 +286,33 @@ func (c *Client[T]) Replace(ctx context.Context, sysID string, in any, opts *Wri
 		}
 	}
 
-	req, err := c.r.NewRequest(ctx, http.MethodPut, recordPath, q, in)
+	c.applyDefaults(q)
+
+	body, err := c.encode(in)
 	if err != nil {
 		return zero, err
 	}
+	undoID, err := c.captureUndo(ctx, UndoReplace, sysID, body)
+	if err != nil {
+		return zero, err
+	}
+
+	req, err := c.r.NewRequest(ctx, http.MethodPut, recordPath, q, body)
+	if err != nil {
+		return zero, c.abortUndo(undoID, err, false)
+	}
 
-	var out resultOne[T]
+	var out resultOne[json.RawMessage]
 	if err := c.r.Do(req, &out); err != nil {
+		return zero, c.abortUndo(undoID, err, true)
+	}
+
+	var record T
+	if err := c.decode(out.Result, &record); err != nil {
 		return zero, err
 	}
 
-	return &WriteResponse[T]{Result: out.Result}, nil
+	return &WriteResponse[T]{Result: record}, nil
 }
 
 func (c *Client[T]) Delete(ctx context.Context, sysID string, opts *DeleteOptions) error {
@@ -240,12 +328,74 @@ func (c *Client[T]) Delete(ctx context.Context, sysID string, opts *DeleteOption
 		}
 	}
 
-	req, err := c.r.NewRequest(ctx, http.MethodDelete, recordPath, q, nil)
+	undoID, err := c.captureUndo(ctx, UndoDelete, sysID, nil)
 	if err != nil {
 		return err
 	}
 
-	return c.r.Do(req, nil)
+	req, err := c.r.NewRequest(ctx, http.MethodDelete, recordPath, q, nil)
+	if err != nil {
+		return c.abortUndo(undoID, err, false)
+	}
+
+	if err := c.r.Do(req, nil); err != nil {
+		return c.abortUndo(undoID, err, true)
+	}
+	return nil
+}
+
+// decode unmarshals a single record, nesting dot-walked keys when enabled.
+func (c *Client[T]) decode(raw json.RawMessage, out *T) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	if c.cfg.dotWalk {
+		return UnmarshalDotWalked(raw, out)
+	}
+	return json.Unmarshal(raw, out)
+}
+
+// encode prepares a write body. With dot-walking enabled, records of type T
+// are flattened and their dot-walked fields dropped, since they are read-only.
+func (c *Client[T]) encode(in any) (any, error) {
+	if !c.cfg.dotWalk {
+		return in, nil
+	}
+	switch in.(type) {
+	case T, *T:
+	default:
+		return in, nil
+	}
+
+	raw, err := MarshalDotWalked(in)
+	if err != nil {
+		return nil, err
+	}
+	if !isJSONObject(raw) {
+		return json.RawMessage(raw), nil
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	for name := range fields {
+		if strings.Contains(name, ".") {
+			delete(fields, name)
+		}
+	}
+
+	return fields, nil
+}
+
+// applyDefaults fills in parameters derived from the client configuration
+// that the per-call options left unset.
+func (c *Client[T]) applyDefaults(q url.Values) {
+	if c.cfg.structFields && q.Get("sysparm_fields") == "" {
+		if fields := joinFields(structFields(reflect.TypeFor[T](), c.cfg.dotWalk)); fields != "" {
+			q.Set("sysparm_fields", fields)
+		}
+	}
 }
 
 func parsePaginationHeaders(headers http.Header) *PaginationMeta {
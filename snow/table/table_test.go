@@ -0,0 +1,34 @@
+package table
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+// newTestRequester returns a snow client for a test server running h.
+func newTestRequester(t *testing.T, h http.HandlerFunc) snow.Requester {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	sc, err := snow.NewClient(snow.WithInstanceURL(srv.URL), snow.WithBasicAuth("admin", "secret"))
+	if err != nil {
+		t.Fatalf("snow.NewClient() error = %v", err)
+	}
+	return sc
+}
+
+// newTestClient returns a client for tableName on a test server running h.
+func newTestClient[T any](t *testing.T, tableName string, h http.HandlerFunc, opts ...Option) *Client[T] {
+	t.Helper()
+
+	c, err := New[T](newTestRequester(t, h), tableName, opts...)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return c
+}
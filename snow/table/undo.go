@@ -0,0 +1,413 @@
+package table
+
+import (
+	"bufio"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+const (
+	UndoUpdate  = "update"
+	UndoReplace = "replace"
+	UndoDelete  = "delete"
+	// UndoAbort marks the entry with the same ID as void: its write failed.
+	UndoAbort = "abort"
+)
+
+var (
+	ErrNilUndoJournal = errors.New("undo journal is nil")
+	ErrRecordChanged  = errors.New("record changed since the journaled write")
+)
+
+// UndoEntry is the before-image of a record captured before a write.
+type UndoEntry struct {
+	ID        string    `json:"id,omitempty"`
+	Time      time.Time `json:"time"`
+	Table     string    `json:"table"`
+	SysID     string    `json:"sys_id"`
+	Operation string    `json:"operation"` // UndoUpdate, UndoReplace, UndoDelete or UndoAbort
+	// Fields are the fields an update wrote; empty for replace and delete,
+	// which affect the whole record.
+	Fields []string       `json:"fields,omitempty"`
+	Before map[string]any `json:"before,omitempty"`
+}
+
+// UndoJournal is an append-only NDJSON file of UndoEntry values. Clients
+// created with WithUndoJournal append to it before every Update, Replace and
+// Delete, including those made by UpdateWhere and DeleteWhere, and append an
+// UndoAbort entry when the instance rejects the write; Rollback reverts the
+// writes that were not aborted.
+type UndoJournal struct {
+	path string
+
+	mu sync.Mutex
+	f  *os.File
+}
+
+// OpenUndoJournal opens the journal at path for appending, creating it if needed.
+func OpenUndoJournal(path string) (*UndoJournal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &UndoJournal{path: path, f: f}, nil
+}
+
+// Path returns the journal file path.
+func (j *UndoJournal) Path() string { return j.path }
+
+// Close closes the journal file.
+func (j *UndoJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.f.Close()
+}
+
+// Append writes e to the journal and syncs the file, so the entry survives a
+// crash during the write it precedes.
+func (j *UndoJournal) Append(e UndoEntry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if _, err := j.f.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return j.f.Sync()
+}
+
+// Entries reads the entries of the journal, oldest first, leaving out
+// aborted writes and the UndoAbort entries marking them.
+func (j *UndoJournal) Entries() ([]UndoEntry, error) {
+	f, err := os.Open(j.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []UndoEntry
+	aborted := map[string]bool{}
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 0, 64*1024), 16<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		var e UndoEntry
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			return nil, fmt.Errorf("undo journal %s line %d: %w", j.path, n, err)
+		}
+		if e.Operation == UndoAbort {
+			aborted[e.ID] = true
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	kept := entries[:0]
+	for _, e := range entries {
+		if e.ID == "" || !aborted[e.ID] {
+			kept = append(kept, e)
+		}
+	}
+	return kept, nil
+}
+
+// captureUndo appends the before-image of sysID to the undo journal, if the
+// client has one, and returns the entry ID ("" without a journal). body is
+// the encoded write body of an update.
+func (c *Client[T]) captureUndo(ctx context.Context, op, sysID string, body any) (string, error) {
+	if c.cfg.undo == nil {
+		return "", nil
+	}
+
+	entry := UndoEntry{
+		ID:        newUndoID(),
+		Time:      time.Now().UTC(),
+		Table:     c.table,
+		SysID:     strings.TrimSpace(sysID),
+		Operation: op,
+	}
+	if op == UndoUpdate {
+		fields, err := bodyFields(body)
+		if err != nil {
+			return "", err
+		}
+		entry.Fields = fields
+	}
+
+	before, err := getRawRecord(ctx, c.r, c.table, entry.SysID)
+	if err != nil {
+		return "", fmt.Errorf("capture before-image of %s/%s: %w", c.table, entry.SysID, err)
+	}
+	entry.Before = before
+
+	if err := c.cfg.undo.Append(entry); err != nil {
+		return "", err
+	}
+	return entry.ID, nil
+}
+
+// abortUndo appends an UndoAbort entry for the entry undoID when err shows
+// that the write was not applied: it was never sent, or the instance rejected
+// it. Other errors, such as a lost connection, leave the entry in place, as
+// the write may have happened. It returns err, joined with any journal error.
+func (c *Client[T]) abortUndo(undoID string, err error, sent bool) error {
+	var apiErr *snow.APIError
+	if undoID == "" || sent && !errors.As(err, &apiErr) && !errors.Is(err, errNotWritten) {
+		return err
+	}
+
+	abort := UndoEntry{ID: undoID, Time: time.Now().UTC(), Table: c.table, Operation: UndoAbort}
+	if jerr := c.cfg.undo.Append(abort); jerr != nil {
+		return errors.Join(err, fmt.Errorf("abort undo entry %s: %w", undoID, jerr))
+	}
+	return err
+}
+
+func newUndoID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// bodyFields returns the top-level keys of a JSON object body, sorted.
+func bodyFields(body any) ([]string, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, fmt.Errorf("update body is not a JSON object: %w", err)
+	}
+
+	fields := make([]string, 0, len(obj))
+	for k := range obj {
+		fields = append(fields, k)
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+// getRawRecord returns all fields of a record as raw values.
+func getRawRecord(ctx context.Context, r snow.Requester, tableName, sysID string) (map[string]any, error) {
+	q := url.Values{}
+	q.Set("sysparm_display_value", string(DisplayValueFalse))
+	q.Set("sysparm_exclude_reference_link", "true")
+
+	req, err := r.NewRequest(ctx, http.MethodGet, path.Join("/api/now/table", tableName, sysID), q, nil)
+	if err != nil {
+		return nil, err
+	}
+	var out resultOne[map[string]any]
+	if err := r.Do(req, &out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
+
+// RollbackOptions configures Rollback.
+type RollbackOptions struct {
+	// Force restores records even if they changed again after the journaled
+	// writes; by default they are reported in Changed and left alone.
+	Force bool
+}
+
+// RollbackResult reports the outcome of Rollback per record.
+type RollbackResult struct {
+	Restored  []string // "table/sys_id" of updated or replaced records set back
+	Recreated []string // "table/sys_id" of deleted records created again
+	Changed   []string // "table/sys_id" of records modified since, left alone
+	Failed    []RecordError
+}
+
+// Rollback reverts the writes recorded in journal, newest first. Each record
+// gets the field values it had before the first journaled write to it:
+// updated fields are patched back, replaced records get all their fields
+// back, and deleted records are created again with their original sys_id
+// (system fields such as sys_created_on are set by the instance).
+//
+// A record whose sys_mod_count moved past the last journaled write was changed
+// by someone else and is reported in Changed unless opts.Force is set. The
+// returned error is only set when the journal cannot be read.
+func Rollback(ctx context.Context, r snow.Requester, journal *UndoJournal, opts *RollbackOptions) (*RollbackResult, error) {
+	if r == nil {
+		return nil, ErrNilRequester
+	}
+	if journal == nil {
+		return nil, ErrNilUndoJournal
+	}
+	entries, err := journal.Entries()
+	if err != nil {
+		return nil, err
+	}
+
+	o := RollbackOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	// Group the entries per record, newest record first.
+	type group struct {
+		key     string
+		entries []UndoEntry // oldest first
+	}
+	var groups []*group
+	byKey := map[string]*group{}
+	for _, e := range entries {
+		key := e.Table + "/" + e.SysID
+		g, ok := byKey[key]
+		if !ok {
+			g = &group{key: key}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.entries = append(g.entries, e)
+	}
+	sort.SliceStable(groups, func(i, k int) bool {
+		a, b := groups[i].entries, groups[k].entries
+		return a[len(a)-1].Time.After(b[len(b)-1].Time)
+	})
+
+	res := &RollbackResult{}
+	for _, g := range groups {
+		if err := ctx.Err(); err != nil {
+			res.Failed = append(res.Failed, RecordError{SysID: g.key, Err: err})
+			continue
+		}
+
+		recreated, err := rollbackRecord(ctx, r, g.entries, o.Force)
+		switch {
+		case errors.Is(err, ErrRecordChanged):
+			res.Changed = append(res.Changed, g.key)
+		case err != nil:
+			res.Failed = append(res.Failed, RecordError{SysID: g.key, Err: err})
+		case recreated:
+			res.Recreated = append(res.Recreated, g.key)
+		default:
+			res.Restored = append(res.Restored, g.key)
+		}
+	}
+	return res, nil
+}
+
+// rollbackRecord reverts the journaled writes to one record, given oldest first.
+func rollbackRecord(ctx context.Context, r snow.Requester, entries []UndoEntry, force bool) (recreated bool, err error) {
+	last := entries[len(entries)-1]
+	tableName, sysID := last.Table, last.SysID
+	if tableName == "" || strings.ContainsAny(tableName, `/\\`) {
+		return false, ErrInvalidTableName
+	}
+	if sysID == "" || strings.ContainsAny(sysID, `/\\`) {
+		return false, ErrInvalidSysID
+	}
+
+	// Walk back from the newest before-image, letting older entries win for
+	// the fields they captured.
+	state := map[string]any{}
+	whole := false
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		if e.Operation == UndoUpdate {
+			for _, f := range e.Fields {
+				state[f] = e.Before[f]
+			}
+			continue
+		}
+		whole = true
+		for f, v := range e.Before {
+			state[f] = v
+		}
+	}
+	if whole {
+		// Start from the full image so fields not touched by older updates
+		// are restored too.
+		full := map[string]any{}
+		for f, v := range last.Before {
+			full[f] = v
+		}
+		for f, v := range state {
+			full[f] = v
+		}
+		state = full
+	}
+
+	current, err := getRawRecord(ctx, r, tableName, sysID)
+	var apiErr *snow.APIError
+	missing := errors.As(err, &apiErr) && apiErr.Status == http.StatusNotFound
+	if err != nil && !missing {
+		return false, err
+	}
+
+	if last.Operation == UndoDelete {
+		if !missing {
+			return false, ErrRecordChanged
+		}
+		body := writableFields(state)
+		body["sys_id"] = sysID
+		req, err := r.NewRequest(ctx, http.MethodPost, path.Join("/api/now/table", tableName), nil, body)
+		if err != nil {
+			return false, err
+		}
+		return true, r.Do(req, nil)
+	}
+
+	if missing {
+		return false, ErrRecordChanged
+	}
+	if !force && modCount(current) > modCount(last.Before)+1 {
+		return false, ErrRecordChanged
+	}
+
+	req, err := r.NewRequest(ctx, http.MethodPatch, path.Join("/api/now/table", tableName, sysID), nil, writableFields(state))
+	if err != nil {
+		return false, err
+	}
+	return false, r.Do(req, nil)
+}
+
+// writableFields drops the system fields the instance maintains.
+func writableFields(record map[string]any) map[string]any {
+	out := make(map[string]any, len(record))
+	for f, v := range record {
+		if strings.HasPrefix(f, "sys_") {
+			continue
+		}
+		out[f] = v
+	}
+	return out
+}
+
+func modCount(record map[string]any) int {
+	switch v := record["sys_mod_count"].(type) {
+	case string:
+		n, _ := strconv.Atoi(v)
+		return n
+	case float64:
+		return int(v)
+	}
+	return 0
+}
@@ -0,0 +1,225 @@
+package table
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	snow "github.com/ggkhrmv/snow-go-sdk/snow"
+)
+
+// newUndoTestServer serves the incident records in records, bumping
+// sys_mod_count on every write. Writes to records with u_locked "true" are
+// rejected.
+func newUndoTestServer(t *testing.T, records map[string]map[string]any) snow.Requester {
+	t.Helper()
+
+	var mu sync.Mutex
+	return newTestRequester(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/now/table/incident"), "/")
+		if r.Method == http.MethodPost {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+				return
+			}
+			body["sys_mod_count"] = "0"
+			records[body["sys_id"].(string)] = body
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{"result": body})
+			return
+		}
+
+		rec, ok := records[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":{"message":"No Record found"},"status":"failure"}`))
+			return
+		}
+		if r.Method != http.MethodGet && rec["u_locked"] == "true" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":{"message":"Operation Failed"},"status":"failure"}`))
+			return
+		}
+		switch r.Method {
+		case http.MethodDelete:
+			delete(records, id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		case http.MethodPatch:
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("decode body: %v", err)
+				return
+			}
+			for k, v := range body {
+				rec[k] = v
+			}
+			n, _ := strconv.Atoi(rec["sys_mod_count"].(string))
+			rec["sys_mod_count"] = strconv.Itoa(n + 1)
+		}
+		json.NewEncoder(w).Encode(map[string]any{"result": rec})
+	})
+}
+
+func TestUndoJournalRollback(t *testing.T) {
+	records := map[string]map[string]any{
+		"a": {"sys_id": "a", "sys_mod_count": "3", "state": "1", "short_description": "Printer"},
+		"b": {"sys_id": "b", "sys_mod_count": "0", "state": "7", "short_description": "Old"},
+		"c": {"sys_id": "c", "sys_mod_count": "5", "state": "1"},
+	}
+	sc := newUndoTestServer(t, records)
+	ctx := context.Background()
+
+	j, err := OpenUndoJournal(filepath.Join(t.TempDir(), "undo.ndjson"))
+	if err != nil {
+		t.Fatalf("OpenUndoJournal() error = %v", err)
+	}
+	defer j.Close()
+
+	c, err := NewMap(sc, "incident", WithUndoJournal(j))
+	if err != nil {
+		t.Fatalf("NewMap() error = %v", err)
+	}
+	if _, err := c.Update(ctx, "a", map[string]string{"state": "2"}, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if _, err := c.Update(ctx, "a", map[string]string{"state": "6", "short_description": "Fixed"}, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := c.Delete(ctx, "b", nil); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := c.Update(ctx, "c", map[string]string{"state": "2"}, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	entries, err := j.Entries()
+	if err != nil {
+		t.Fatalf("Entries() error = %v", err)
+	}
+	if len(entries) != 4 || entries[1].SysID != "a" || !slices.Equal(entries[1].Fields, []string{"short_description", "state"}) || entries[2].Operation != UndoDelete {
+		t.Fatalf("Entries() = %+v", entries)
+	}
+
+	// Someone else changes c after our write.
+	records["c"]["state"] = "3"
+	records["c"]["sys_mod_count"] = "7"
+
+	res, err := Rollback(ctx, sc, j, nil)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if !slices.Equal(res.Restored, []string{"incident/a"}) || !slices.Equal(res.Recreated, []string{"incident/b"}) ||
+		!slices.Equal(res.Changed, []string{"incident/c"}) || len(res.Failed) != 0 {
+		t.Fatalf("Rollback() = %+v", res)
+	}
+	if a := records["a"]; a["state"] != "1" || a["short_description"] != "Printer" {
+		t.Errorf("a = %v", a)
+	}
+	if b := records["b"]; b == nil || b["state"] != "7" || b["short_description"] != "Old" {
+		t.Errorf("b = %v", b)
+	}
+	if records["c"]["state"] != "3" {
+		t.Errorf("c = %v", records["c"])
+	}
+
+	res, err = Rollback(ctx, sc, j, &RollbackOptions{Force: true})
+	if err != nil {
+		t.Fatalf("Rollback() force error = %v", err)
+	}
+	if records["c"]["state"] != "1" || !slices.Contains(res.Restored, "incident/c") {
+		t.Errorf("Rollback() force = %+v, c = %v", res, records["c"])
+	}
+}
+
+func TestUndoJournalWriteFailure(t *testing.T) {
+	records := map[string]map[string]any{
+		"a": {"sys_id": "a", "sys_mod_count": "0", "state": "1"},
+		"l": {"sys_id": "l", "sys_mod_count": "2", "state": "1", "u_locked": "true"},
+	}
+	sc := newUndoTestServer(t, records)
+	ctx := context.Background()
+
+	j, err := OpenUndoJournal(filepath.Join(t.TempDir(), "undo.ndjson"))
+	if err != nil {
+		t.Fatalf("OpenUndoJournal() error = %v", err)
+	}
+	defer j.Close()
+
+	c, err := NewMap(sc, "incident", WithUndoJournal(j))
+	if err != nil {
+		t.Fatalf("NewMap() error = %v", err)
+	}
+	if _, err := c.Update(ctx, "a", map[string]string{"state": "2"}, nil); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	var apiErr *snow.APIError
+	if _, err := c.Update(ctx, "l", map[string]string{"state": "2"}, nil); !errors.As(err, &apiErr) || apiErr.Status != http.StatusForbidden {
+		t.Fatalf("Update() locked error = %v, want 403 APIError", err)
+	}
+	if err := c.Delete(ctx, "l", nil); !errors.As(err, &apiErr) || apiErr.Status != http.StatusForbidden {
+		t.Fatalf("Delete() locked error = %v, want 403 APIError", err)
+	}
+
+	// The before-images of the failed writes were captured, then aborted.
+	raw, err := os.ReadFile(j.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(string(raw), `"operation":"abort"`); n != 2 {
+		t.Fatalf("journal has %d abort entries, want 2:\n%s", n, raw)
+	}
+	entries, err := j.Entries()
+	if err != nil || len(entries) != 1 || entries[0].SysID != "a" {
+		t.Fatalf("Entries() = %+v, %v, want the update of a", entries, err)
+	}
+
+	res, err := Rollback(ctx, sc, j, nil)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if !slices.Equal(res.Restored, []string{"incident/a"}) || len(res.Changed) != 0 || len(res.Failed) != 0 {
+		t.Fatalf("Rollback() = %+v", res)
+	}
+	if records["a"]["state"] != "1" || records["l"] == nil {
+		t.Fatalf("records = %v", records)
+	}
+}
+
+func TestUndoJournalCaptureFailure(t *testing.T) {
+	sc := newUndoTestServer(t, map[string]map[string]any{})
+
+	j, err := OpenUndoJournal(filepath.Join(t.TempDir(), "undo.ndjson"))
+	if err != nil {
+		t.Fatalf("OpenUndoJournal() error = %v", err)
+	}
+	defer j.Close()
+
+	c, err := NewMap(sc, "incident", WithUndoJournal(j))
+	if err != nil {
+		t.Fatalf("NewMap() error = %v", err)
+	}
+	var apiErr *snow.APIError
+	if err := c.Delete(context.Background(), "missing", nil); !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+		t.Fatalf("Delete() error = %v, want 404 APIError", err)
+	}
+	if entries, err := j.Entries(); err != nil || len(entries) != 0 {
+		t.Fatalf("Entries() = %v, %v, want none", entries, err)
+	}
+
+	if _, err := NewMap(sc, "incident", WithUndoJournal(nil)); !errors.Is(err, ErrNilUndoJournal) {
+		t.Fatalf("NewMap() error = %v, want ErrNilUndoJournal", err)
+	}
+}
@@ -0,0 +1,128 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultWaitInterval = 5 * time.Second
+
+var (
+	ErrWaitTimeout   = errors.New("timed out waiting for record state")
+	ErrNilPredicate  = errors.New("wait predicate is nil")
+	ErrInvalidFactor = errors.New("BackoffFactor must be >= 1")
+)
+
+// WaitOptions configures WaitFor.
+type WaitOptions[T any] struct {
+	// Interval between polls; defaults to 5s.
+	Interval time.Duration
+	// BackoffFactor multiplies the interval after every poll; 0 or 1 polls at a fixed interval.
+	BackoffFactor float64
+	// MaxInterval caps the interval when backing off.
+	MaxInterval time.Duration
+
+	// GetOptions are passed to every Get, e.g. to limit Fields.
+	GetOptions *GetOptions
+
+	// OnPoll is called with every record fetched, before the predicate is checked.
+	OnPoll func(attempt int, record T)
+}
+
+// WaitTimeoutError is returned by WaitFor when ctx is done before the
+// predicate is satisfied. It matches ErrWaitTimeout with errors.Is and
+// unwraps to the context error.
+type WaitTimeoutError[T any] struct {
+	SysID    string
+	Attempts int
+	// LastSeen is the last record fetched; HasLastSeen is false if no poll succeeded.
+	LastSeen    T
+	HasLastSeen bool
+
+	Err error
+}
+
+func (e *WaitTimeoutError[T]) Error() string {
+	return fmt.Sprintf("%s: sys_id %s after %d attempts: %v", ErrWaitTimeout, e.SysID, e.Attempts, e.Err)
+}
+
+func (e *WaitTimeoutError[T]) Is(target error) bool { return target == ErrWaitTimeout }
+
+func (e *WaitTimeoutError[T]) Unwrap() error { return e.Err }
+
+// WaitFor polls the record sysID until predicate returns true and returns that
+// record. The overall timeout comes from ctx:
+//
+//	ctx, cancel := context.WithTimeout(ctx, 30*time.Minute)
+//	defer cancel()
+//	chg, err := table.WaitFor(ctx, changes, sysID, func(c Change) bool {
+//		return c.State == "-1" // Implement
+//	}, nil)
+//
+// API errors other than the context ending are returned as is.
+func WaitFor[T any](ctx context.Context, c *Client[T], sysID string, predicate func(T) bool, opts *WaitOptions[T]) (T, error) {
+	var zero T
+
+	if c == nil {
+		return zero, ErrNilRequester
+	}
+	if predicate == nil {
+		return zero, ErrNilPredicate
+	}
+
+	o := WaitOptions[T]{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultWaitInterval
+	}
+	if o.BackoffFactor == 0 {
+		o.BackoffFactor = 1
+	}
+	if o.BackoffFactor < 1 {
+		return zero, ErrInvalidFactor
+	}
+
+	timeoutErr := &WaitTimeoutError[T]{SysID: sysID}
+	interval := o.Interval
+
+	for {
+		timeoutErr.Attempts++
+
+		resp, err := c.Get(ctx, sysID, o.GetOptions)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				timeoutErr.Err = ctxErr
+				return zero, timeoutErr
+			}
+			return zero, err
+		}
+
+		record := resp.Result
+		timeoutErr.LastSeen, timeoutErr.HasLastSeen = record, true
+
+		if o.OnPoll != nil {
+			o.OnPoll(timeoutErr.Attempts, record)
+		}
+		if predicate(record) {
+			return record, nil
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			timeoutErr.Err = ctx.Err()
+			return zero, timeoutErr
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * o.BackoffFactor)
+		if o.MaxInterval > 0 && interval > o.MaxInterval {
+			interval = o.MaxInterval
+		}
+	}
+}
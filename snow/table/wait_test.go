@@ -0,0 +1,62 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type waitTestRecord struct {
+	SysID string `json:"sys_id"`
+	State string `json:"state"`
+}
+
+func TestWaitFor(t *testing.T) {
+	var polls atomic.Int32
+	c := newTestClient[waitTestRecord](t, "change_request", func(w http.ResponseWriter, r *http.Request) {
+		state := "-2"
+		if polls.Add(1) >= 3 {
+			state = "-1"
+		}
+		fmt.Fprintf(w, `{"result":{"sys_id":"chg1","state":%q}}`, state)
+	})
+
+	var seen []string
+	rec, err := WaitFor(context.Background(), c, "chg1", func(r waitTestRecord) bool {
+		return r.State == "-1"
+	}, &WaitOptions[waitTestRecord]{
+		Interval: time.Millisecond,
+		OnPoll:   func(_ int, r waitTestRecord) { seen = append(seen, r.State) },
+	})
+	if err != nil {
+		t.Fatalf("WaitFor() error = %v", err)
+	}
+	if rec.State != "-1" || len(seen) != 3 {
+		t.Fatalf("WaitFor() = %+v after %v", rec, seen)
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	c := newTestClient[waitTestRecord](t, "change_request", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"result":{"sys_id":"chg1","state":"-2"}}`)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := WaitFor(ctx, c, "chg1", func(r waitTestRecord) bool { return false }, &WaitOptions[waitTestRecord]{
+		Interval: 5 * time.Millisecond,
+	})
+	if !errors.Is(err, ErrWaitTimeout) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("WaitFor() error = %v, want %v", err, ErrWaitTimeout)
+	}
+
+	var timeoutErr *WaitTimeoutError[waitTestRecord]
+	if !errors.As(err, &timeoutErr) || !timeoutErr.HasLastSeen || timeoutErr.LastSeen.State != "-2" {
+		t.Fatalf("WaitFor() error = %#v, want last seen state", err)
+	}
+}
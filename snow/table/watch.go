@@ -0,0 +1,196 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultWatchBackoffFactor = 2
+	defaultWatchMaxInterval   = time.Minute
+)
+
+var ErrNilWatchHandler = errors.New("watch handler is nil")
+
+// WatchEventType tells whether a watched record was inserted or updated.
+type WatchEventType string
+
+const (
+	WatchInsert WatchEventType = "insert"
+	WatchUpdate WatchEventType = "update"
+)
+
+// WatchEvent is a change of a watched record.
+type WatchEvent[T any] struct {
+	Type      WatchEventType
+	SysID     string
+	ModCount  int // sys_mod_count after the change
+	UpdatedOn time.Time
+	Record    T
+}
+
+// WatchOptions configures Watch.
+type WatchOptions struct {
+	// Interval between polls while changes arrive; defaults to 5s.
+	Interval time.Duration
+	// BackoffFactor multiplies the interval after every poll without changes,
+	// up to MaxInterval; defaults to 2. 1 polls at a fixed interval.
+	BackoffFactor float64
+	// MaxInterval caps the idle interval; defaults to 1m.
+	MaxInterval time.Duration
+
+	Fields   []string
+	PageSize int
+
+	// Since is where watching starts when Store has no cursor. The zero value
+	// starts at the time Watch is called, skipping existing records.
+	Since Checkpoint
+	// Store persists the cursor after every handled page.
+	Store CheckpointStore
+	// Key is the Store key; defaults to "watch:" + the table name.
+	Key string
+
+	// OnError is called when a poll fails. Returning nil keeps watching
+	// (backing off as if idle); otherwise Watch stops with that error. Without
+	// OnError, Watch stops on the first failed poll.
+	OnError func(err error) error
+}
+
+type watchSeen struct {
+	modCount  int
+	updatedOn time.Time
+}
+
+// Watch polls the table for records matching query (an encoded query without
+// ^NQ or ORDERBY) and calls handler for every insert and update, oldest first.
+// It runs until ctx is done, returning ctx.Err(), or until handler returns an
+// error.
+//
+// Delivery is at least once: the cursor only moves past a page after handler
+// accepted every event in it, so a page interrupted by an error or restart is
+// delivered again. Within one Watch, a change already delivered (same sys_id
+// and sys_mod_count) is not repeated.
+func (c *Client[T]) Watch(ctx context.Context, query string, handler func(context.Context, WatchEvent[T]) error, opts *WatchOptions) error {
+	if c == nil || c.r == nil {
+		return ErrNilRequester
+	}
+	if handler == nil {
+		return ErrNilWatchHandler
+	}
+
+	o := WatchOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Interval <= 0 {
+		o.Interval = defaultWaitInterval
+	}
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = defaultWatchMaxInterval
+	}
+	if o.BackoffFactor == 0 {
+		o.BackoffFactor = defaultWatchBackoffFactor
+	}
+	if o.BackoffFactor < 1 {
+		return ErrInvalidFactor
+	}
+	if _, err := changesQuery(Checkpoint{}, query); err != nil {
+		return err
+	}
+
+	key := o.Key
+	if key == "" {
+		key = "watch:" + c.table
+	}
+
+	cp := o.Since
+	if cp.IsZero() {
+		cp = Checkpoint{UpdatedOn: time.Now().UTC().Truncate(time.Second)}
+	}
+	if o.Store != nil {
+		stored, ok, err := o.Store.Load(ctx, key)
+		if err != nil {
+			return err
+		}
+		if ok {
+			cp = stored
+		}
+	}
+
+	syncOpts := &SyncOptions{Query: query, Fields: o.Fields, PageSize: o.PageSize}
+	seen := map[string]watchSeen{}
+	interval := o.Interval
+
+	for {
+		page, metas, err := c.changes(ctx, cp, syncOpts)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return ctxErr
+			}
+			if o.OnError == nil {
+				return err
+			}
+			if err := o.OnError(err); err != nil {
+				return err
+			}
+			page = &ChangesResponse[T]{Next: cp}
+		}
+
+		for i, record := range page.Result {
+			meta := metas[i]
+			modCount, _ := strconv.Atoi(meta.ModCount)
+
+			prev, ok := seen[meta.SysID]
+			if ok && prev.modCount >= modCount {
+				continue
+			}
+			seen[meta.SysID] = watchSeen{modCount: modCount, updatedOn: meta.updatedOn}
+
+			event := WatchEvent[T]{
+				Type:      WatchUpdate,
+				SysID:     meta.SysID,
+				ModCount:  modCount,
+				UpdatedOn: meta.updatedOn,
+				Record:    record,
+			}
+			if modCount == 0 {
+				event.Type = WatchInsert
+			}
+			if err := handler(ctx, event); err != nil {
+				return err
+			}
+		}
+
+		if len(page.Result) > 0 {
+			if o.Store != nil {
+				if err := o.Store.Save(ctx, key, page.Next); err != nil {
+					return err
+				}
+			}
+			cp = page.Next
+			interval = o.Interval
+
+			// Records older than the cursor cannot be returned again.
+			for id, s := range seen {
+				if s.updatedOn.Before(cp.UpdatedOn) {
+					delete(seen, id)
+				}
+			}
+		} else {
+			interval = min(time.Duration(float64(interval)*o.BackoffFactor), o.MaxInterval)
+		}
+		if page.More {
+			continue
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package table
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		polls []time.Time
+	)
+	c := newTestClient[syncTestRecord](t, "incident", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		polls = append(polls, time.Now())
+		n := len(polls)
+		mu.Unlock()
+
+		q := r.URL.Query().Get("sysparm_query")
+		switch n {
+		case 1:
+			want := "sys_updated_on>=2024-01-15 10:00:00^assignment_group=g1^ORDERBYsys_updated_on^ORDERBYsys_id"
+			if q != want {
+				t.Errorf("sysparm_query = %q, want %q", q, want)
+			}
+			w.Write([]byte(`{"result":[
+				{"sys_id":"a","number":"INC1","sys_updated_on":"2024-01-15 10:00:05","sys_mod_count":"0"},
+				{"sys_id":"b","number":"INC2","sys_updated_on":"2024-01-15 10:00:07","sys_mod_count":"3"}
+			]}`))
+		case 2, 3, 4:
+			w.Write([]byte(`{"result":[]}`))
+		default:
+			w.Write([]byte(`{"result":[
+				{"sys_id":"a","number":"INC1","sys_updated_on":"2024-01-15 10:01:00","sys_mod_count":"1"}
+			]}`))
+		}
+	}, WithStructFields())
+	store, err := NewFileCheckpointStore(filepath.Join(t.TempDir(), "cursor.json"))
+	if err != nil {
+		t.Fatalf("NewFileCheckpointStore() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var events []WatchEvent[syncTestRecord]
+	err = c.Watch(ctx, "assignment_group=g1", func(_ context.Context, e WatchEvent[syncTestRecord]) error {
+		events = append(events, e)
+		if len(events) == 3 {
+			cancel()
+		}
+		return nil
+	}, &WatchOptions{
+		Interval:    10 * time.Millisecond,
+		MaxInterval: 40 * time.Millisecond,
+		Since:       Checkpoint{UpdatedOn: time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)},
+		Store:       store,
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Watch() error = %v, want context.Canceled", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("len(events) = %d, want 3", len(events))
+	}
+	if events[0].Type != WatchInsert || events[1].Type != WatchUpdate || events[1].ModCount != 3 {
+		t.Errorf("first page = %+v", events[:2])
+	}
+	if e := events[2]; e.Type != WatchUpdate || e.Record.Number != "INC1" || e.ModCount != 1 {
+		t.Errorf("events[2] = %+v", e)
+	}
+
+	// Idle polls back off by the default factor of 2 up to MaxInterval.
+	mu.Lock()
+	defer mu.Unlock()
+	for i, want := range []time.Duration{20 * time.Millisecond, 40 * time.Millisecond, 40 * time.Millisecond} {
+		if gap := polls[i+2].Sub(polls[i+1]); gap < want {
+			t.Errorf("interval after idle poll %d = %v, want >= %v", i+2, gap, want)
+		}
+	}
+
+	cp, ok, err := store.Load(context.Background(), "watch:incident")
+	if err != nil || !ok || cp.SysID != "a" || cp.UpdatedOn.Minute() != 1 {
+		t.Fatalf("stored cursor = %+v, %v, %v", cp, ok, err)
+	}
+}
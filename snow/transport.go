@@ -7,6 +7,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 type Doer interface {
@@ -38,15 +39,21 @@ func (c *Client) NewRequest(ctx context.Context, method, p string, query url.Val
 		u.RawQuery = query.Encode()
 	}
 
-	var r io.Reader
+	var (
+		r io.Reader
+		b []byte
+	)
 	if body != nil {
-		b, err := json.Marshal(body)
-		if err != nil {
+		if b, err = json.Marshal(body); err != nil {
 			return nil, err
 		}
 		r = bytes.NewReader(b)
 	}
 
+	if err := c.checkWrite(ctx, method, p, b); err != nil {
+		return nil, err
+	}
+
 	req, err := http.NewRequestWithContext(ctx, method, u.String(), r)
 	if err != nil {
 		return nil, err
@@ -92,19 +99,19 @@ func (c *Client) do(req *http.Request, out any, preserveBody bool) (*http.Respon
 		return nil, ErrMissingHTTPClient
 	}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, err
+	var (
+		resp *http.Response
+		raw  []byte
+		err  error
+	)
+	if p := c.apiPath(req.URL.Path); c.dryRun != nil && isWriteMethod(req.Method) && !isReadOnlyPost(req.Method, p) {
+		resp, raw, err = c.dryRun.respond(req, p, c.send)
+	} else {
+		resp, raw, err = c.send(req)
 	}
-
-	raw, err := io.ReadAll(resp.Body)
-	closeErr := resp.Body.Close()
 	if err != nil {
 		return resp, err
 	}
-	if closeErr != nil {
-		return resp, closeErr
-	}
 
 	if preserveBody {
 		resp.Body = io.NopCloser(bytes.NewReader(raw))
@@ -124,3 +131,42 @@ func (c *Client) do(req *http.Request, out any, preserveBody bool) (*http.Respon
 
 	return resp, nil
 }
+
+// send sends req to the instance and reads the whole response body.
+func (c *Client) send(req *http.Request) (*http.Response, []byte, error) {
+	if c.limiter != nil {
+		if err := c.limiter.wait(req.Context()); err != nil {
+			return nil, nil, err
+		}
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	raw, err := io.ReadAll(resp.Body)
+	closeErr := resp.Body.Close()
+	if err != nil {
+		return resp, nil, err
+	}
+	if closeErr != nil {
+		return resp, nil, closeErr
+	}
+	return resp, raw, nil
+}
+
+// apiPath returns the path of a request URL relative to the instance base URL.
+func (c *Client) apiPath(p string) string {
+	if c.baseURL == nil || c.baseURL.Path == "" {
+		return p
+	}
+	return "/" + strings.TrimLeft(strings.TrimPrefix(p, c.baseURL.Path), "/")
+}
+
+func isWriteMethod(method string) bool {
+	switch strings.ToUpper(method) {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+var jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+
+// Decode decodes the record of e into T. Fields sent in the
+// sysparm_display_value=all shape ({"value", "display_value"}) decode into
+// table.FieldValue fields as is, and into plain fields (string, number, bool)
+// as their value. String values such as "1" or "true" decode into number and
+// bool fields. Fields of T are matched by json tag, as table.JSONFieldTypes
+// reads them.
+func Decode[T any](e *Event) (T, error) {
+	var out T
+	if e == nil || len(e.Record) == 0 {
+		return out, fmt.Errorf("%w: no record", ErrInvalidPayload)
+	}
+
+	data, err := flattenDisplayValues(e.Record, reflect.TypeFor[T]())
+	if err != nil {
+		return out, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if err := json.Unmarshal(data, &out); err != nil {
+		return out, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	return out, nil
+}
+
+func flattenDisplayValues(data json.RawMessage, t reflect.Type) (json.RawMessage, error) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fieldType func(name string) (reflect.Type, bool)
+	switch t.Kind() {
+	case reflect.Struct:
+		fields := table.JSONFieldTypes(t)
+		fieldType = func(name string) (reflect.Type, bool) {
+			ft, ok := fields[name]
+			return ft, ok
+		}
+	case reflect.Map:
+		fieldType = func(string) (reflect.Type, bool) { return t.Elem(), true }
+	default:
+		return data, nil
+	}
+
+	var record map[string]json.RawMessage
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for name, raw := range record {
+		ft, ok := fieldType(name)
+		if !ok || !scalarType(ft) {
+			continue
+		}
+
+		value := raw
+		var dv struct {
+			Value        *json.RawMessage `json:"value"`
+			DisplayValue *json.RawMessage `json:"display_value"`
+		}
+		if len(raw) > 0 && raw[0] == '{' && json.Unmarshal(raw, &dv) == nil && dv.Value != nil && dv.DisplayValue != nil {
+			value = *dv.Value
+		}
+		if value = unquoteScalar(value, ft); !bytes.Equal(value, raw) {
+			record[name] = value
+			changed = true
+		}
+	}
+
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(record)
+}
+
+// unquoteScalar turns the string values ServiceNow sends for numbers and
+// booleans into JSON numbers and booleans when t needs them.
+func unquoteScalar(value json.RawMessage, t reflect.Type) json.RawMessage {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() == reflect.String || len(value) == 0 || value[0] != '"' {
+		return value
+	}
+
+	var s string
+	if err := json.Unmarshal(value, &s); err != nil {
+		return value
+	}
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return json.RawMessage("null")
+	}
+	if !json.Valid([]byte(s)) {
+		return value
+	}
+	return json.RawMessage(s)
+}
+
+// scalarType reports whether t is a plain value that cannot hold a
+// {"value", "display_value"} object.
+func scalarType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		return false
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultSignatureHeader = "X-Snow-Signature"
+	DefaultSecretHeader    = "X-Snow-Secret"
+
+	defaultDedupeTTL    = 10 * time.Minute
+	defaultMaxBodyBytes = 1 << 20
+)
+
+// Option configures a Handler.
+type Option func(*Handler) error
+
+// WithHMACSecret verifies requests by the HMAC-SHA256 of the body in header
+// (DefaultSignatureHeader when empty), base64 or hex encoded, optionally
+// prefixed with "sha256=". In the business rule:
+//
+//	var mac = new GlideCertificateEncryption().generateMac(gs.base64Encode(secret), 'HmacSHA256', body);
+//	request.setRequestHeader('X-Snow-Signature', mac);
+func WithHMACSecret(secret, header string) Option {
+	return func(h *Handler) error {
+		if secret == "" {
+			return errors.New("hmac secret cannot be empty")
+		}
+		h.hmacSecret = []byte(secret)
+		h.signatureHeader = headerOrDefault(header, DefaultSignatureHeader)
+		return nil
+	}
+}
+
+// WithSharedSecret verifies requests by a static secret in header
+// (DefaultSecretHeader when empty). Prefer WithHMACSecret, which also
+// protects the body.
+func WithSharedSecret(secret, header string) Option {
+	return func(h *Handler) error {
+		if secret == "" {
+			return errors.New("shared secret cannot be empty")
+		}
+		h.sharedSecret = []byte(secret)
+		h.secretHeader = headerOrDefault(header, DefaultSecretHeader)
+		return nil
+	}
+}
+
+// WithDedupeTTL sets how long a delivered change is remembered to drop
+// retries of it; defaults to 10m. Zero disables deduplication.
+func WithDedupeTTL(ttl time.Duration) Option {
+	return func(h *Handler) error {
+		if ttl < 0 {
+			return errors.New("dedupe TTL cannot be negative")
+		}
+		h.dedupeTTL = ttl
+		return nil
+	}
+}
+
+// WithMaxBodyBytes limits the size of request bodies; defaults to 1 MiB.
+func WithMaxBodyBytes(n int64) Option {
+	return func(h *Handler) error {
+		if n <= 0 {
+			return errors.New("max body bytes must be > 0")
+		}
+		h.maxBodyBytes = n
+		return nil
+	}
+}
+
+func headerOrDefault(header, def string) string {
+	if header = strings.TrimSpace(header); header != "" {
+		return http.CanonicalHeaderKey(header)
+	}
+	return def
+}
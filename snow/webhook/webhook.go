@@ -0,0 +1,350 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+var (
+	ErrNoVerification   = errors.New("webhook requires WithHMACSecret or WithSharedSecret")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+	ErrNilHandlerFunc   = errors.New("webhook handler func is nil")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// Operation is the database operation that triggered the business rule.
+type Operation string
+
+const (
+	OperationInsert Operation = "insert"
+	OperationUpdate Operation = "update"
+	OperationDelete Operation = "delete"
+)
+
+// Event is a change sent by a ServiceNow business rule. The expected body is
+//
+//	{"table": "incident", "operation": "update", "sys_id": "...", "sys_mod_count": "3", "record": {...}}
+//
+// with operation as returned by current.operation(). sys_id and sys_mod_count
+// are taken from the record when missing; record may use the
+// sysparm_display_value=all shape (see Decode).
+type Event struct {
+	Table     string
+	Operation Operation
+	SysID     string
+	ModCount  int
+	Record    json.RawMessage
+}
+
+// HandlerFunc handles an Event. Returning an error answers the request with
+// 500, so ServiceNow can retry it; wrap ErrInvalidPayload to answer 400.
+type HandlerFunc func(ctx context.Context, e *Event) error
+
+type route struct {
+	table string
+	op    Operation
+	fn    HandlerFunc
+}
+
+type delivery struct {
+	expires  time.Time
+	inFlight bool
+}
+
+// Handler is an http.Handler receiving ServiceNow outbound REST messages. It
+// verifies the request, drops repeated deliveries of the same change (by
+// table, operation, sys_id and sys_mod_count) and dispatches the event to
+// every matching handler in registration order.
+//
+// Responses: 204 when handled (or a duplicate), 400 for undecodable payloads,
+// 401 for failed verification, 409 while the same change is being handled by
+// another request, 500 when a handler fails.
+type Handler struct {
+	hmacSecret      []byte
+	signatureHeader string
+	sharedSecret    []byte
+	secretHeader    string
+	dedupeTTL       time.Duration
+	maxBodyBytes    int64
+
+	mu        sync.RWMutex
+	routes    []route
+	seen      map[string]delivery
+	lastPrune time.Time
+}
+
+func New(opts ...Option) (*Handler, error) {
+	h := &Handler{
+		dedupeTTL:    defaultDedupeTTL,
+		maxBodyBytes: defaultMaxBodyBytes,
+		seen:         map[string]delivery{},
+	}
+	for _, opt := range opts {
+		if err := opt(h); err != nil {
+			return nil, err
+		}
+	}
+	if h.hmacSecret == nil && h.sharedSecret == nil {
+		return nil, ErrNoVerification
+	}
+	return h, nil
+}
+
+// Handle registers fn for events of tableName and op. An empty tableName or
+// op matches any.
+func (h *Handler) Handle(tableName string, op Operation, fn HandlerFunc) error {
+	if fn == nil {
+		return ErrNilHandlerFunc
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.routes = append(h.routes, route{
+		table: strings.TrimSpace(tableName),
+		op:    Operation(strings.ToLower(string(op))),
+		fn:    fn,
+	})
+	return nil
+}
+
+// On registers fn like Handle, decoding the record into T with Decode first.
+func On[T any](h *Handler, tableName string, op Operation, fn func(ctx context.Context, e *Event, record T) error) error {
+	if fn == nil {
+		return ErrNilHandlerFunc
+	}
+	return h.Handle(tableName, op, func(ctx context.Context, e *Event) error {
+		record, err := Decode[T](e)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, e, record)
+	})
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.maxBodyBytes))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "cannot read request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.verify(r.Header, body); err != nil {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	e, err := decodeEvent(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := fmt.Sprintf("%s|%s|%s|%d", e.Table, e.Operation, e.SysID, e.ModCount)
+	switch h.reserve(key) {
+	case errDuplicate:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case errInFlight:
+		http.Error(w, "change is being handled", http.StatusConflict)
+		return
+	}
+
+	if err := h.dispatch(r.Context(), e); err != nil {
+		h.release(key)
+		if errors.Is(err, ErrInvalidPayload) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "handler failed", http.StatusInternalServerError)
+		return
+	}
+
+	h.complete(key)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) dispatch(ctx context.Context, e *Event) error {
+	h.mu.RLock()
+	routes := h.routes
+	h.mu.RUnlock()
+
+	for _, rt := range routes {
+		if (rt.table != "" && rt.table != e.Table) || (rt.op != "" && rt.op != e.Operation) {
+			continue
+		}
+		if err := rt.fn(ctx, e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) verify(header http.Header, body []byte) error {
+	if h.sharedSecret != nil {
+		got := []byte(header.Get(h.secretHeader))
+		if subtle.ConstantTimeCompare(got, h.sharedSecret) != 1 {
+			return ErrInvalidSignature
+		}
+	}
+
+	if h.hmacSecret != nil {
+		sig := strings.TrimSpace(header.Get(h.signatureHeader))
+		sig = strings.TrimPrefix(sig, "sha256=")
+		if sig == "" {
+			return ErrInvalidSignature
+		}
+
+		mac := hmac.New(sha256.New, h.hmacSecret)
+		mac.Write(body)
+		want := mac.Sum(nil)
+
+		got, err := hex.DecodeString(sig)
+		if err != nil {
+			if got, err = base64.StdEncoding.DecodeString(sig); err != nil {
+				return ErrInvalidSignature
+			}
+		}
+		if !hmac.Equal(got, want) {
+			return ErrInvalidSignature
+		}
+	}
+	return nil
+}
+
+func decodeEvent(body []byte) (*Event, error) {
+	var env struct {
+		Table     string           `json:"table"`
+		Operation string           `json:"operation"`
+		SysID     table.FieldValue `json:"sys_id"`
+		ModCount  table.FieldValue `json:"sys_mod_count"`
+		Record    json.RawMessage  `json:"record"`
+	}
+	if err := json.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+
+	if len(env.Record) > 0 && (env.SysID.Value == "" || env.ModCount.Value == "") {
+		var rec struct {
+			SysID    table.FieldValue `json:"sys_id"`
+			ModCount table.FieldValue `json:"sys_mod_count"`
+		}
+		if err := json.Unmarshal(env.Record, &rec); err != nil {
+			return nil, fmt.Errorf("%w: record: %v", ErrInvalidPayload, err)
+		}
+		if env.SysID.Value == "" {
+			env.SysID = rec.SysID
+		}
+		if env.ModCount.Value == "" {
+			env.ModCount = rec.ModCount
+		}
+	}
+
+	e := &Event{
+		Table:     strings.TrimSpace(env.Table),
+		Operation: Operation(strings.ToLower(strings.TrimSpace(env.Operation))),
+		SysID:     strings.TrimSpace(env.SysID.Value),
+		Record:    env.Record,
+	}
+	if env.ModCount.Value != "" {
+		n, err := strconv.Atoi(env.ModCount.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: sys_mod_count %q", ErrInvalidPayload, env.ModCount.Value)
+		}
+		e.ModCount = n
+	}
+
+	switch {
+	case e.Table == "":
+		return nil, fmt.Errorf("%w: missing table", ErrInvalidPayload)
+	case e.SysID == "":
+		return nil, fmt.Errorf("%w: missing sys_id", ErrInvalidPayload)
+	}
+	switch e.Operation {
+	case OperationInsert, OperationUpdate, OperationDelete:
+	default:
+		return nil, fmt.Errorf("%w: unknown operation %q", ErrInvalidPayload, e.Operation)
+	}
+
+	return e, nil
+}
+
+var (
+	errDuplicate = errors.New("duplicate delivery")
+	errInFlight  = errors.New("delivery in flight")
+)
+
+// reserve marks key as being handled, unless it was handled recently or is
+// being handled now.
+func (h *Handler) reserve(key string) error {
+	if h.dedupeTTL == 0 {
+		return nil
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(h.lastPrune) > h.dedupeTTL/2 {
+		for k, d := range h.seen {
+			if !d.inFlight && now.After(d.expires) {
+				delete(h.seen, k)
+			}
+		}
+		h.lastPrune = now
+	}
+
+	if d, ok := h.seen[key]; ok {
+		if d.inFlight {
+			return errInFlight
+		}
+		if now.Before(d.expires) {
+			return errDuplicate
+		}
+	}
+	h.seen[key] = delivery{inFlight: true}
+	return nil
+}
+
+func (h *Handler) complete(key string) {
+	if h.dedupeTTL == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.seen[key] = delivery{expires: time.Now().Add(h.dedupeTTL)}
+}
+
+func (h *Handler) release(key string) {
+	if h.dedupeTTL == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.seen, key)
+}
@@ -0,0 +1,153 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ggkhrmv/snow-go-sdk/snow/table"
+)
+
+type incident struct {
+	SysID    string           `json:"sys_id"`
+	Number   string           `json:"number"`
+	Priority int              `json:"priority"`
+	State    table.FieldValue `json:"state"`
+}
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func post(h http.Handler, body, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/snow", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(DefaultSignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestHandler(t *testing.T) {
+	h, err := New(WithHMACSecret("s3cret", ""))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var got []incident
+	err = On(h, "incident", OperationUpdate, func(_ context.Context, e *Event, rec incident) error {
+		if e.ModCount != 4 {
+			t.Errorf("ModCount = %d, want 4", e.ModCount)
+		}
+		got = append(got, rec)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("On() error = %v", err)
+	}
+
+	body := `{"table":"incident","operation":"update","record":{
+		"sys_id":{"value":"inc1","display_value":"inc1"},
+		"sys_mod_count":{"value":"4","display_value":"4"},
+		"number":{"value":"INC0010001","display_value":"INC0010001"},
+		"priority":{"value":"1","display_value":"1 - Critical"},
+		"state":{"value":"2","display_value":"In Progress"}
+	}}`
+
+	if code := post(h, body, "sha256="+sign("wrong", body)); code != http.StatusUnauthorized {
+		t.Fatalf("bad signature: status = %d, want 401", code)
+	}
+	if code := post(h, body, sign("s3cret", body)); code != http.StatusNoContent {
+		t.Fatalf("status = %d, want 204", code)
+	}
+	// A retry of the same change is acknowledged but not dispatched again.
+	if code := post(h, body, sign("s3cret", body)); code != http.StatusNoContent {
+		t.Fatalf("retry: status = %d, want 204", code)
+	}
+
+	if len(got) != 1 {
+		t.Fatalf("handled %d events, want 1", len(got))
+	}
+	if r := got[0]; r.SysID != "inc1" || r.Number != "INC0010001" || r.Priority != 1 || r.State.DisplayValue != "In Progress" {
+		t.Fatalf("record = %+v", r)
+	}
+}
+
+type TaskBase struct {
+	Number   string `json:"number"`
+	Priority int    `json:"priority"`
+}
+
+type embeddedIncident struct {
+	*TaskBase
+	Active bool `json:"active"`
+}
+
+func TestDecodeEmbedded(t *testing.T) {
+	e := &Event{Record: []byte(`{
+		"number":{"value":"INC0010001","display_value":"INC0010001"},
+		"priority":{"value":"2","display_value":"2 - High"},
+		"active":"true"
+	}`)}
+
+	rec, err := Decode[embeddedIncident](e)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if rec.TaskBase == nil || rec.Number != "INC0010001" || rec.Priority != 2 || !rec.Active {
+		t.Fatalf("Decode() = %+v", rec)
+	}
+}
+
+func TestHandlerFailureAllowsRetry(t *testing.T) {
+	h, err := New(WithSharedSecret("token", ""))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	calls := 0
+	_ = h.Handle("", "", func(context.Context, *Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("downstream unavailable")
+		}
+		return nil
+	})
+
+	send := func(body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/snow", strings.NewReader(body))
+		req.Header.Set(DefaultSecretHeader, "token")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	body := `{"table":"incident","operation":"insert","sys_id":"inc1","sys_mod_count":0,"record":{"number":"INC1"}}`
+	if code := send(body); code != http.StatusInternalServerError {
+		t.Fatalf("first delivery: status = %d, want 500", code)
+	}
+	if code := send(body); code != http.StatusNoContent {
+		t.Fatalf("retry: status = %d, want 204", code)
+	}
+	if code := send(`{"table":"incident","operation":"merge","sys_id":"inc1"}`); code != http.StatusBadRequest {
+		t.Fatalf("unknown operation: status = %d, want 400", code)
+	}
+	if calls != 2 {
+		t.Fatalf("calls = %d, want 2", calls)
+	}
+}
+
+func TestNewRequiresVerification(t *testing.T) {
+	if _, err := New(); !errors.Is(err, ErrNoVerification) {
+		t.Fatalf("New() error = %v, want ErrNoVerification", err)
+	}
+}